@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage named version aliases",
+	Long:  "Manage user-defined aliases of versions, e.g. work-java pointing to java 17.0.9, usable wherever version is expected",
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:     "set <alias> <software> <version>",
+	Short:   "Set alias to version of software",
+	Example: "svm alias set work-java java 17.0.9\nsvm alias set prod-node node lts/iron",
+	Args:    cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[1])
+		if err != nil {
+			console.Fatal(err)
+		}
+		err = software.SetAlias(plugin, args[0], args[2])
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:     "remove <alias> <software>",
+	Aliases: []string{"rm"},
+	Short:   "Remove alias of software",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[1])
+		if err != nil {
+			console.Fatal(err)
+		}
+		err = software.RemoveAlias(plugin, args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List aliases",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := software.DisplayAliases()
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd, aliasRemoveCmd, aliasListCmd)
+	RootCmd.AddCommand(aliasCmd)
+}
@@ -25,11 +25,13 @@ import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
 	"github.com/spf13/cobra"
 )
 
 func AvailableCmd(name, longName string) *cobra.Command {
-	return &cobra.Command{
+	var preReleases bool
+	command := &cobra.Command{
 		Use:     "available [version]",
 		Aliases: []string{"i", "install"},
 		Short:   fmt.Sprintf("Display available versions of %v", name),
@@ -37,7 +39,12 @@ func AvailableCmd(name, longName string) *cobra.Command {
 		Args:    VersionArg,
 		Run: func(cmd *cobra.Command, args []string) {
 			plugin := domain.GetPlugin(name)
-			software.Available(plugin)
+			err := software.Available(cmd.Context(), plugin, FirstOrEmpty(args), preReleases)
+			if err != nil {
+				console.Fatal(err)
+			}
 		},
 	}
+	command.Flags().BoolVar(&preReleases, "pre", false, "Include pre-releases (rc, beta, alpha, ea, eap)")
+	return command
 }
@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/cmd"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software/declarative"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"os"
+	"path/filepath"
+)
+
+// Register adds plugins defined in ~/.soft-ver-man/plugins/*.yaml, it must be called after built-in plugins are registered
+func Register() {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	definitions, err := declarative.ReadDefinitions(filepath.Join(homeDir, config.HomeConfigDir, config.PluginsDir))
+	if err != nil {
+		console.Error(err)
+		return
+	}
+	for _, definition := range definitions {
+		if domain.GetPlugin(definition.Name) != nil {
+			console.Error(fmt.Errorf("%v: plugin %v already exists", definition.File, definition.Name))
+			continue
+		}
+		domain.Register(definition.Plugin())
+		cmd.RootCmd.AddCommand(cmd.PluginCmd(definition.Name, definition.LongName, definition.Aliases))
+	}
+}
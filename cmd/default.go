@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+)
+
+var defaultCmd = &cobra.Command{
+	Use:   "default [software] [version]",
+	Short: "Make installed software package main version",
+	Long:  "Make installed software package main version without reinstalling it",
+	Args:  SoftwareAndVersionMandatoryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		err = software.SetMain(plugin, args[1])
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func DefaultCmd(name, longName string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "default [version]",
+		Aliases: []string{"d", "default"},
+		Short:   "Make installed software package main version",
+		Long:    fmt.Sprintf("Make installed %v main version without reinstalling it", longName),
+		Args:    VersionMandatoryArg,
+		Run: func(cmd *cobra.Command, args []string) {
+			plugin := domain.GetPlugin(name)
+			err := software.SetMain(plugin, FirstOrEmpty(args))
+			if err != nil {
+				console.Fatal(err)
+			}
+		},
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(defaultCmd)
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+	"text/tabwriter"
+)
+
+var detectCmd = &cobra.Command{
+	Use:   "detect",
+	Short: "Display versions wanted by the project",
+	Long: `Display versions of software wanted by the project in the current directory (or its parents)
+found in files of the ecosystems, e.g. .nvmrc, go.mod, .sdkmanrc or .java-version`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := os.Getwd()
+		if err != nil {
+			console.Fatal(err)
+		}
+		detectedVersions := project.Detect(dir)
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', tabwriter.Debug)
+		if len(detectedVersions) == 0 {
+			_, err = fmt.Fprintln(w, "No versions found")
+		} else {
+			_, err = fmt.Fprintln(w, "Software\t Version\t File")
+		}
+		if err != nil {
+			console.Fatal(err)
+		}
+		for _, detectedVersion := range detectedVersions {
+			_, err = fmt.Fprintf(w, "%s\t %s\t %s\n", detectedVersion.Plugin.Info().Name, detectedVersion.ProjectVersion.Version, detectedVersion.ProjectVersion.File)
+			if err != nil {
+				console.Fatal(err)
+			}
+		}
+		err = w.Flush()
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(detectCmd)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+)
+
+var cleanPath bool
+
+var envCmd = &cobra.Command{
+	Use:   "env [software] [version]",
+	Short: "Print environment variables of installed software package",
+	Long: `Print export lines that switch installed software package to given version in the current shell only.
+
+The output is meant to be evaluated, e.g. eval "$(svm env node 20)".
+Shell function installed by 'svm init' does it with: svm use node 20`,
+	Args: SoftwareAndVersionMandatoryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		lines, err := software.SessionExports(plugin, args[1], cleanPath)
+		if err != nil {
+			console.Fatal(err)
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(envCmd)
+	envCmd.Flags().BoolVarP(&cleanPath, "clean-path", "p", false, "Remove other versions of software from PATH (default: false)")
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [software@version]... -- [command] [args]",
+	Short: "Run command with given software versions",
+	Long: `Run a single command with given versions of installed software, e.g.
+  svm exec java@11 maven@3.8 -- mvn package
+  svm exec "node@>=18 <21" -- npm test
+  svm exec node@lts/iron -- npm test
+Neither main versions nor the current shell are changed. Exit code of the command is passed through.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 0 || dash == len(args) {
+			return errors.New("command to run must follow --")
+		}
+		if dash == 0 {
+			return errors.New("at least one software@version is required")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		dash := cmd.ArgsLenAtDash()
+		items := make([]software.ExecItem, 0, dash)
+		for _, spec := range args[:dash] {
+			item, err := parseExecItem(spec)
+			if err != nil {
+				console.Fatal(err)
+			}
+			items = append(items, item)
+		}
+		exitCode, err := software.Exec(cmd.Context(), items, args[dash], args[dash+1:])
+		if err != nil {
+			console.Fatal(err)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+func parseExecItem(spec string) (software.ExecItem, error) {
+	name, version, found := strings.Cut(spec, "@")
+	if !found || name == "" || version == "" {
+		return software.ExecItem{}, errors.New("Expected software@version, got: " + spec)
+	}
+	plugin, err := FindPlugin(name)
+	if err != nil {
+		return software.ExecItem{}, err
+	}
+	return software.ExecItem{Plugin: plugin, Version: version}, nil
+}
+
+func init() {
+	RootCmd.AddCommand(execCmd)
+}
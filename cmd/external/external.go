@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/cmd"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software/external"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"os"
+	"path/filepath"
+)
+
+// Register adds plugins implemented by svm-plugin-* executables from ~/.soft-ver-man/plugins or PATH,
+// it must be called after built-in plugins are registered
+func Register() {
+	dirs := make([]string, 0)
+	var cache *external.InfoCache
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
+		dirs = append(dirs, filepath.Join(homeDir, config.HomeConfigDir, config.PluginsDir))
+		cache = external.ReadInfoCache(filepath.Join(homeDir, config.HomeConfigDir, config.PluginsInfoCacheFile))
+	}
+	for _, executable := range external.Discover(dirs...) {
+		info, err := external.Load(executable, cache)
+		if err != nil {
+			console.Error(err)
+			continue
+		}
+		if domain.GetPlugin(info.Name) != nil {
+			console.Error(fmt.Errorf("%v: plugin %v already exists", executable, info.Name))
+			continue
+		}
+		domain.Register(info.Plugin())
+		cmd.RootCmd.AddCommand(cmd.PluginCmd(info.Name, info.LongName, info.Aliases))
+	}
+	if cache != nil {
+		err = cache.Save()
+		if err != nil {
+			console.Warn(err)
+		}
+	}
+}
@@ -30,8 +30,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func FetchCmd(name, longName string, verifyChecksum *bool) *cobra.Command {
-	return &cobra.Command{
+func FetchCmd(name, longName string) *cobra.Command {
+	var verifyChecksum, preReleases bool
+	command := &cobra.Command{
 		Use:     "fetch [version]",
 		Aliases: []string{"f", "fetch"},
 		Short:   "Fetch software package into download directory",
@@ -43,10 +44,15 @@ func FetchCmd(name, longName string, verifyChecksum *bool) *cobra.Command {
 			if err != nil {
 				console.Fatal(err)
 			}
-			_, err = software.Fetch(plugin, FirstOrEmpty(args), configuration.SoftwareDownloadDir, *verifyChecksum)
+			_, err = software.Fetch(cmd.Context(), plugin, FirstOrEmpty(args), configuration.SoftwareDownloadDir, verifyChecksum, preReleases)
 			if err != nil {
 				console.Fatal(err)
 			}
 		},
 	}
+	command.Flags().BoolVar(&preReleases, "pre", false, "Allow pre-releases (rc, beta, alpha, ea, eap) when resolving version")
+	if _, ok := domain.GetPlugin(name).(domain.ChecksumVerifier); ok {
+		command.Flags().BoolVarP(&verifyChecksum, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	}
+	return command
 }
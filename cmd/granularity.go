@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+)
+
+var granularityCmd = &cobra.Command{
+	Use:   "granularity [software] [MAJOR|MINOR|PATCH|FULL]",
+	Short: "Show or set granularity of versioned variables",
+	Long: `Show or set granularity of versioned variables of software, e.g. for java 17.0.9
+  MAJOR - JAVA_17_HOME
+  MINOR - JAVA_17_0_HOME
+  PATCH - JAVA_17_0_9_HOME
+  FULL  - variable per raw version string
+When several installed packages share a variable, it points to the newest one. Rc file is regenerated on change.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		if len(args) == 1 {
+			software.DisplayVersionGranularity(plugin)
+			return
+		}
+		err = software.SetVersionGranularity(plugin, args[1])
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(granularityCmd)
+}
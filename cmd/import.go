@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+var asdfDir string
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import software installed by other version managers",
+	Long:  "Import software installed by other version managers",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+var importAsdfCmd = &cobra.Command{
+	Use:   "asdf",
+	Short: "Import software installed by asdf",
+	Long:  "Adopt software installed by asdf as installed packages without downloading it again",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := asdfDir
+		if dir == "" {
+			dir = defaultAsdfDir()
+		}
+		err := software.ImportAsdf(dir)
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func defaultAsdfDir() string {
+	if dataDir := os.Getenv("ASDF_DATA_DIR"); dataDir != "" {
+		return dataDir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		console.Fatal(err)
+	}
+	return filepath.Join(homeDir, ".asdf")
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importAsdfCmd)
+	importAsdfCmd.Flags().StringVarP(&asdfDir, "asdf-dir", "d", "", "asdf data directory (default: $ASDF_DATA_DIR or ~/.asdf)")
+}
@@ -30,18 +30,29 @@ import (
 )
 
 func InstallCmd(name, longName string, options software.InstallOptions) *cobra.Command {
-	return &cobra.Command{
+	if options.VerifyChecksum == nil {
+		options.VerifyChecksum = new(bool)
+	}
+	if options.PreReleases == nil {
+		options.PreReleases = new(bool)
+	}
+	command := &cobra.Command{
 		Use:     "install [version]",
 		Aliases: []string{"i", "install"},
 		Short:   "Install software package from software directory",
-		Long:    fmt.Sprintf("Install %v from software directory", longName),
+		Long:    fmt.Sprintf("Install %v from software directory, version may be a constraint like ^18.2, ~3.8.1, \">=17 <21\" or \"18.x || 20.x\", a channel like latest, stable, lts or lts/iron, or an alias set by svm alias", longName),
 		Args:    VersionArg,
 		Run: func(cmd *cobra.Command, args []string) {
 			plugin := domain.GetPlugin(name)
-			err := software.Install(plugin, FirstOrEmpty(args), options)
+			err := software.Install(cmd.Context(), plugin, FirstOrEmpty(args), options)
 			if err != nil {
 				console.Fatal(err)
 			}
 		},
 	}
+	command.Flags().BoolVar(options.PreReleases, "pre", false, "Allow pre-releases (rc, beta, alpha, ea, eap) when resolving version")
+	if _, ok := domain.GetPlugin(name).(domain.ChecksumVerifier); ok {
+		command.Flags().BoolVarP(options.VerifyChecksum, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	}
+	return command
 }
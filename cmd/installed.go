@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/config"
 	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +34,10 @@ var allInstalledCmd = &cobra.Command{
 	Short: "Display installed packages",
 	Long:  "Display installed packages",
 	Run: func(cmd *cobra.Command, args []string) {
-		config.DisplayAllInstalledPackages()
+		err := config.DisplayAllInstalledPackages()
+		if err != nil {
+			console.Fatal(err)
+		}
 	},
 }
 
@@ -44,7 +48,10 @@ func InstalledCmd(name string) *cobra.Command {
 		Long:  fmt.Sprintf("Display installed packages for %v", name),
 		Run: func(cmd *cobra.Command, args []string) {
 			plugin := domain.GetPlugin(name)
-			config.DisplayInstalledPackages(plugin.Name)
+			err := config.DisplayInstalledPackages(plugin.Info().Name)
+			if err != nil {
+				console.Fatal(err)
+			}
 		},
 	}
 }
@@ -22,23 +22,72 @@
 package intellij
 
 import (
+	"fmt"
 	"github.com/pkk82/soft-ver-man/cmd"
+	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/software"
 	"github.com/pkk82/soft-ver-man/software/intellij"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
 )
 
-var verifyChecksum bool
-var main bool
-var here bool
-var Cmd = cmd.MainCmd(intellij.Name, intellij.LongName, intellij.Aliases)
-
 func init() {
-	cmd.RootCmd.AddCommand(Cmd)
-	installCmd := cmd.InstallCmd(intellij.Name, intellij.LongName, software.InstallOptions{VerifyChecksum: &verifyChecksum, Main: &main, Here: &here})
-	Cmd.AddCommand(installCmd)
-	Cmd.AddCommand(cmd.UninstallCmd(intellij.Name, intellij.LongName))
+	for _, product := range intellij.Products {
+		cmd.RootCmd.AddCommand(productCmd(product))
+	}
+}
+
+// productCmd creates command of JetBrains IDE, all of them share installation, isolation and launchers
+func productCmd(product intellij.Product) *cobra.Command {
+	var main bool
+	var here bool
+	var isolated bool
 
-	installCmd.Flags().BoolVarP(&verifyChecksum, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	mainCmd := cmd.MainCmd(product.Name, product.LongName, product.Aliases)
+	mainCmd.AddCommand(cmd.FetchCmd(product.Name, product.LongName))
+	isolate := func(installedPackage domain.InstalledPackage) error {
+		if !isolated {
+			return nil
+		}
+		return intellij.Isolate(product.Name, installedPackage)
+	}
+	installCmd := cmd.InstallCmd(product.Name, product.LongName, software.InstallOptions{Main: &main, Here: &here, PostInstall: isolate})
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
+	installCmd.Flags().BoolVar(&isolated, "isolated", false, "Keep settings, caches, plugins and logs of the version in its own directory (default: false)")
+	mainCmd.AddCommand(installCmd)
+	mainCmd.AddCommand(cmd.UninstallCmd(product.Name, product.LongName))
+	mainCmd.AddCommand(cmd.InstalledCmd(product.Name))
+	mainCmd.AddCommand(cmd.AvailableCmd(product.Name, product.LongName))
+	mainCmd.AddCommand(cmd.DefaultCmd(product.Name, product.LongName))
+	mainCmd.AddCommand(cloneSettingsCmd(product))
+	return mainCmd
+}
+
+func cloneSettingsCmd(product intellij.Product) *cobra.Command {
+	var force bool
+	cloneSettingsCmd := &cobra.Command{
+		Use:     "clone-settings <from> <to>",
+		Short:   "Copy settings between installed versions",
+		Long:    "Copy settings (and plugins if both versions are isolated) of one installed version to another, e.g. before switching to isolated version. Each version must match exactly one installed version",
+		Example: fmt.Sprintf("svm %s clone-settings 2023.3 2024.1", product.Name),
+		Args:    cobra.ExactArgs(2),
+		Run: func(command *cobra.Command, args []string) {
+			plugin := domain.GetPlugin(product.Name)
+			from, err := software.FindSingleInstalledPackage(plugin, args[0])
+			if err != nil {
+				console.Fatal(err)
+			}
+			to, err := software.FindSingleInstalledPackage(plugin, args[1])
+			if err != nil {
+				console.Fatal(err)
+			}
+			err = intellij.CloneSettings(product.Name, from, to, force)
+			if err != nil {
+				console.Fatal(err)
+			}
+		},
+	}
+	cloneSettingsCmd.Flags().BoolVarP(&force, "force", "f", false, "Override settings of target version (default: false)")
+	return cloneSettingsCmd
 }
@@ -22,31 +22,83 @@
 package java
 
 import (
+	"fmt"
 	"github.com/pkk82/soft-ver-man/cmd"
 	"github.com/pkk82/soft-ver-man/software"
 	"github.com/pkk82/soft-ver-man/software/java"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
 )
 
 var Cmd = cmd.MainCmd(java.Name, java.LongName, java.Aliases)
 
-var verifyChecksumFetch bool
-var verifyChecksumInstall bool
 var archivePath string
 var main bool
 var here bool
+var syncToolchains bool
 
 func init() {
 	cmd.RootCmd.AddCommand(Cmd)
-	fetchCmd := cmd.FetchCmd(java.Name, java.LongName, &verifyChecksumFetch)
-	fetchCmd.Flags().BoolVarP(&verifyChecksumFetch, "verify-checksum", "c", false, "Verify checksum of downloaded file")
-	Cmd.AddCommand(fetchCmd)
-	installCmd := cmd.InstallCmd(java.Name, java.LongName, software.InstallOptions{VerifyChecksum: &verifyChecksumInstall, ArchivePath: &archivePath, Main: &main, Here: &here})
-	installCmd.Flags().BoolVarP(&verifyChecksumInstall, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	Cmd.AddCommand(withPackageFlags(cmd.FetchCmd(java.Name, java.LongName)))
+	installCmd := cmd.InstallCmd(java.Name, java.LongName, software.InstallOptions{ArchivePath: &archivePath, Main: &main, Here: &here})
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
 	installCmd.Flags().StringVarP(&archivePath, "archive-path", "f", "", "Specify path to archive file")
-	Cmd.AddCommand(installCmd)
+	Cmd.AddCommand(withPackageFlags(installCmd))
 	Cmd.AddCommand(cmd.UninstallCmd(java.Name, java.LongName))
 	Cmd.AddCommand(cmd.InstalledCmd(java.Name))
-	Cmd.AddCommand(cmd.AvailableCmd(java.Name, java.LongName))
+	Cmd.AddCommand(withPackageFlags(cmd.AvailableCmd(java.Name, java.LongName)))
+	Cmd.AddCommand(cmd.DefaultCmd(java.Name, java.LongName))
+	toolchainsCmd.Flags().BoolVarP(&syncToolchains, "sync", "s", false, "Write toolchains to ~/.m2/toolchains.xml instead of printing them (default: false)")
+	Cmd.AddCommand(toolchainsCmd)
+}
+
+var toolchainsCmd = &cobra.Command{
+	Use:   "toolchains",
+	Short: "Print or sync Maven toolchains.xml",
+	Long: `Print or sync ~/.m2/toolchains.xml with installed JDKs.
+Installed JDKs are kept in a section managed by soft-ver-man, toolchains added by hand are kept as they are.
+The section is synced automatically after each install and uninstall.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if syncToolchains {
+			err := java.SyncToolchains()
+			if err != nil {
+				console.Fatal(err)
+			}
+			return
+		}
+		content, err := java.Toolchains()
+		if err != nil {
+			console.Fatal(err)
+		}
+		fmt.Print(content)
+	},
+}
+
+// withPackageFlags lets user choose vendor and flavour of the package, musl builds are chosen on musl hosts automatically
+func withPackageFlags(command *cobra.Command) *cobra.Command {
+	var vendor, packageType string
+	var javafx, crac bool
+	command.Flags().StringVar(&vendor, "vendor", "", "Vendor: zulu, tem, corretto, graalce or liberica (default: zulu)")
+	command.Flags().StringVar(&packageType, "package", "", "Package type: jdk or jre (default: jdk)")
+	command.Flags().BoolVar(&javafx, "javafx", false, "Package with JavaFX bundled (default: false)")
+	command.Flags().BoolVar(&crac, "crac", false, "Package supporting CRaC (default: false)")
+	cmd.QualifyVersionArg(command, java.Name, func() map[string]string {
+		options := make(map[string]string)
+		if vendor != "" {
+			options["vendor"] = vendor
+		}
+		if packageType != "" {
+			options["package"] = packageType
+		}
+		if javafx {
+			options["javafx"] = "true"
+		}
+		if crac {
+			options["crac"] = "true"
+		}
+		return options
+	})
+	return command
 }
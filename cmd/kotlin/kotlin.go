@@ -29,22 +29,18 @@ import (
 
 var Cmd = cmd.MainCmd(kotlin.Name, kotlin.Name, kotlin.Aliases)
 
-var verifyChecksumFetch bool
-var verifyChecksumInstall bool
 var main bool
 var here bool
 
 func init() {
 	cmd.RootCmd.AddCommand(Cmd)
-	fetchCmd := cmd.FetchCmd(kotlin.Name, kotlin.Name, &verifyChecksumFetch)
-	fetchCmd.Flags().BoolVarP(&verifyChecksumFetch, "verify-checksum", "c", false, "Verify checksum of downloaded file")
-	Cmd.AddCommand(fetchCmd)
-	installCmd := cmd.InstallCmd(kotlin.Name, kotlin.Name, software.InstallOptions{VerifyChecksum: &verifyChecksumInstall, Main: &main, Here: &here})
-	installCmd.Flags().BoolVarP(&verifyChecksumInstall, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	Cmd.AddCommand(cmd.FetchCmd(kotlin.Name, kotlin.Name))
+	installCmd := cmd.InstallCmd(kotlin.Name, kotlin.Name, software.InstallOptions{Main: &main, Here: &here})
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
 	Cmd.AddCommand(installCmd)
 	Cmd.AddCommand(cmd.UninstallCmd(kotlin.Name, kotlin.Name))
 	Cmd.AddCommand(cmd.InstalledCmd(kotlin.Name))
 	Cmd.AddCommand(cmd.AvailableCmd(kotlin.Name, kotlin.Name))
+	Cmd.AddCommand(cmd.DefaultCmd(kotlin.Name, kotlin.Name))
 }
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+)
+
+var launchersCmd = &cobra.Command{
+	Use:   "launchers",
+	Short: "Manage desktop launchers",
+	Long: `Manage desktop launchers of installed packages, e.g. IntelliJ IDEA .desktop files.
+Launchers have environment variables of installed packages baked in, so they are regenerated whenever installed packages change.`,
+}
+
+var launchersListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List managed launchers",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := software.DisplayLaunchers()
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+var launchersRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Regenerate managed launchers with current environment",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := software.RefreshLaunchers()
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+var launchersRemoveCmd = &cobra.Command{
+	Use:     "remove <software> [version]",
+	Aliases: []string{"rm"},
+	Short:   "Remove launchers of software matching version, all of them if version is not given",
+	Example: "svm launchers remove intellij 2024.1.2\nsvm launchers remove intellij 2024.1\nsvm launchers remove intellij",
+	Args:    cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		version := ""
+		if len(args) == 2 {
+			version = args[1]
+		}
+		err = software.RemoveLaunchers(plugin, version)
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	launchersCmd.AddCommand(launchersListCmd, launchersRefreshCmd, launchersRemoveCmd)
+	RootCmd.AddCommand(launchersCmd)
+}
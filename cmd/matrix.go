@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix [software] [versions] -- [command] [args]",
+	Short: "Run command across several versions of software",
+	Long: `Run the same command with each of comma separated versions of software, e.g.
+  svm matrix node 18,20,22 -- npm test
+Missing versions are installed first. Exit code is non-zero if the command fails for any version.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 0 || dash == len(args) {
+			return errors.New("command to run must follow --")
+		}
+		if dash != 2 {
+			return errors.New("software and comma separated versions are required before --")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		versions := make([]string, 0)
+		for _, version := range strings.Split(args[1], ",") {
+			if strings.TrimSpace(version) != "" {
+				versions = append(versions, strings.TrimSpace(version))
+			}
+		}
+		if len(versions) == 0 {
+			console.Fatal(errors.New("at least one version is required"))
+		}
+		parallel, err := cmd.Flags().GetBool("parallel")
+		if err != nil {
+			console.Fatal(err)
+		}
+
+		results, err := software.Matrix(cmd.Context(), plugin, versions, args[2], args[3:], parallel)
+		if err != nil {
+			console.Fatal(err)
+		}
+		err = software.DisplayMatrixResults(plugin, results)
+		if err != nil {
+			console.Fatal(err)
+		}
+		for _, result := range results {
+			if !result.Passed() {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(matrixCmd)
+	matrixCmd.Flags().BoolP("parallel", "p", false, "Run versions in parallel with prefixed output")
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/pkk82/soft-ver-man/software/maven"
 )
 
-var verifyChecksum bool
 var main bool
 var here bool
 
@@ -35,11 +34,11 @@ var Cmd = cmd.MainCmd(maven.Name, maven.LongName, maven.Aliases)
 
 func init() {
 	cmd.RootCmd.AddCommand(Cmd)
-	installCmd := cmd.InstallCmd(maven.Name, maven.LongName, software.InstallOptions{VerifyChecksum: &verifyChecksum, Main: &main, Here: &here})
+	installCmd := cmd.InstallCmd(maven.Name, maven.LongName, software.InstallOptions{Main: &main, Here: &here})
 	Cmd.AddCommand(installCmd)
 	Cmd.AddCommand(cmd.UninstallCmd(maven.Name, maven.LongName))
+	Cmd.AddCommand(cmd.DefaultCmd(maven.Name, maven.LongName))
 
-	installCmd.Flags().BoolVarP(&verifyChecksum, "verify-checksum", "c", false, "Verify checksum of downloaded file")
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
 }
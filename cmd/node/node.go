@@ -29,23 +29,19 @@ import (
 
 var Cmd = cmd.MainCmd(node.Name, node.LongName, node.Aliases)
 
-var verifyChecksumFetch bool
-var verifyChecksumInstall bool
 var main bool
 var here bool
 
 func init() {
 	cmd.RootCmd.AddCommand(Cmd)
-	fetchCmd := cmd.FetchCmd(node.Name, node.LongName, &verifyChecksumFetch)
-	fetchCmd.Flags().BoolVarP(&verifyChecksumFetch, "verify-checksum", "c", false, "Verify checksum of downloaded file")
-	Cmd.AddCommand(fetchCmd)
-	installCmd := cmd.InstallCmd(node.Name, node.LongName, software.InstallOptions{VerifyChecksum: &verifyChecksumInstall, Main: &main, Here: &here})
-	installCmd.Flags().BoolVarP(&verifyChecksumInstall, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	Cmd.AddCommand(cmd.FetchCmd(node.Name, node.LongName))
+	installCmd := cmd.InstallCmd(node.Name, node.LongName, software.InstallOptions{Main: &main, Here: &here})
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
 	Cmd.AddCommand(installCmd)
 	Cmd.AddCommand(cmd.UninstallCmd(node.Name, node.LongName))
 	Cmd.AddCommand(cmd.InstalledCmd(node.Name))
 	Cmd.AddCommand(cmd.AvailableCmd(node.Name, node.LongName))
+	Cmd.AddCommand(cmd.DefaultCmd(node.Name, node.LongName))
 
 }
@@ -0,0 +1,49 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/spf13/cobra"
+)
+
+// PluginCmd creates command of plugin registered at runtime with the standard subcommands
+func PluginCmd(name, longName string, aliases []string) *cobra.Command {
+	var main bool
+	var here bool
+
+	mainCmd := MainCmd(name, longName, aliases)
+	mainCmd.AddCommand(FetchCmd(name, longName))
+	installCmd := InstallCmd(name, longName, software.InstallOptions{Main: &main, Here: &here})
+	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
+	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
+	mainCmd.AddCommand(installCmd)
+	mainCmd.AddCommand(UninstallCmd(name, longName))
+	mainCmd.AddCommand(InstalledCmd(name))
+	if _, ok := domain.GetPlugin(name).(domain.Lister); ok {
+		mainCmd.AddCommand(AvailableCmd(name, longName))
+	}
+	mainCmd.AddCommand(DefaultCmd(name, longName))
+	return mainCmd
+}
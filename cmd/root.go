@@ -96,11 +96,17 @@ func initConfig() {
 		displayError(err)
 	}
 
-	if err := viper.ReadInConfig(); err == nil {
+	if err := viper.ReadInConfig(); err == nil && !isHiddenCommand() {
 		displayMessageOnStdErr("Using config file:", viper.ConfigFileUsed())
 	}
 }
 
+// hidden commands are run by shell hooks and shims, so they should not clutter the output
+func isHiddenCommand() bool {
+	command, _, err := RootCmd.Find(os.Args[1:])
+	return err == nil && command.Hidden
+}
+
 func displayMessageOnStdErr(msgs ...any) {
 	_, err := fmt.Fprintln(os.Stderr, msgs...)
 	if err != nil {
@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var reshimCmd = &cobra.Command{
+	Use:   "reshim",
+	Short: "Regenerate shims of installed software",
+	Long: `Regenerate shims - small launcher scripts for every executable of installed software.
+Each shim runs the version wanted by the project in the current directory or the main version.
+Shims directory should be added to PATH of applications not reading shell rc files (GUI apps, cron jobs, IDEs).
+Once generated, shims are regenerated after each install and uninstall.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		disable, err := cmd.Flags().GetBool("disable")
+		if err != nil {
+			console.Fatal(err)
+		}
+		if disable {
+			err = software.RemoveShims()
+		} else {
+			err = software.EnableShims()
+		}
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+var shimExecCmd = &cobra.Command{
+	Use:                "shim-exec [software] [executable] [args]",
+	Short:              "Run executable of software in the version of the current directory",
+	Args:               cobra.MinimumNArgs(2),
+	Hidden:             true,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		plugin, err := FindPlugin(args[0])
+		if err != nil {
+			console.Fatal(err)
+		}
+		exitCode, err := software.RunShim(plugin, args[1], args[2:])
+		if err != nil {
+			console.Fatal(err)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(reshimCmd)
+	RootCmd.AddCommand(shimExecCmd)
+	reshimCmd.Flags().Bool("disable", false, "Remove shims and stop regenerating them")
+}
@@ -29,22 +29,18 @@ import (
 
 var Cmd = cmd.MainCmd(svm.Name, svm.LongName, svm.Aliases)
 
-var verifyChecksumFetch bool
-var verifyChecksumInstall bool
 var main bool
 var here bool
 
 func init() {
 	cmd.RootCmd.AddCommand(Cmd)
-	fetchCmd := cmd.FetchCmd(svm.Name, svm.LongName, &verifyChecksumFetch)
-	fetchCmd.Flags().BoolVarP(&verifyChecksumFetch, "verify-checksum", "c", false, "Verify checksum of downloaded file")
-	Cmd.AddCommand(fetchCmd)
-	installCmd := cmd.InstallCmd(svm.Name, svm.LongName, software.InstallOptions{VerifyChecksum: &verifyChecksumInstall, Main: &main, Here: &here})
-	installCmd.Flags().BoolVarP(&verifyChecksumInstall, "verify-checksum", "c", false, "Verify checksum of downloaded file")
+	Cmd.AddCommand(cmd.FetchCmd(svm.Name, svm.LongName))
+	installCmd := cmd.InstallCmd(svm.Name, svm.LongName, software.InstallOptions{Main: &main, Here: &here})
 	installCmd.Flags().BoolVarP(&main, "main", "m", false, "Make package main version (default: false)")
 	installCmd.Flags().BoolVarP(&here, "here", "x", false, "Use package in the current directory via .direnv (default: false)")
 	Cmd.AddCommand(installCmd)
 	Cmd.AddCommand(cmd.UninstallCmd(svm.Name, svm.LongName))
 	Cmd.AddCommand(cmd.InstalledCmd(svm.Name))
 	Cmd.AddCommand(cmd.AvailableCmd(svm.Name, svm.LongName))
+	Cmd.AddCommand(cmd.DefaultCmd(svm.Name, svm.LongName))
 }
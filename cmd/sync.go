@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var frozen bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Install software listed in project manifest",
+	Long: `Install software listed in project manifest (svm.yaml or asdf .tool-versions) and use it in the project directory via .direnv.
+
+Exact versions, download urls and SHA-256 checksums of artifacts are written to svm.lock, separately for each os and architecture.
+With --frozen exactly the artifacts from svm.lock are installed, it fails if svm.lock does not match the manifest.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := os.Getwd()
+		if err != nil {
+			console.Fatal(err)
+		}
+		manifest, found, err := project.FindManifest(dir)
+		if err != nil {
+			console.Fatal(err)
+		}
+		if !found {
+			console.Fatal(errors.New("no " + project.ManifestFileName + " nor " + project.ToolVersionsFileName + " found in " + dir))
+		}
+		items := make([]software.SyncItem, len(manifest.Tools))
+		for i, tool := range manifest.Tools {
+			plugin, err := FindPlugin(tool.Name)
+			if err != nil {
+				console.Fatal(err)
+			}
+			items[i] = software.SyncItem{Plugin: plugin, Version: tool.Version, Options: tool.Options}
+		}
+		err = software.Sync(cmd.Context(), items, dir, frozen)
+		if err != nil {
+			console.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVarP(&frozen, "frozen", "", false, "Install exactly the artifacts from svm.lock and fail if checksum differs (default: false)")
+}
@@ -23,7 +23,10 @@
 package cmd
 
 import (
+	"errors"
 	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software"
+	"github.com/pkk82/soft-ver-man/util/console"
 	"github.com/spf13/cobra"
 )
 
@@ -35,7 +38,7 @@ func VersionArg(cmd *cobra.Command, args []string) error {
 	if firstArgOrEmpty == "" {
 		return nil
 	}
-	return domain.ValidateVersion(firstArgOrEmpty)
+	return validateVersionSelector(commandPlugin(cmd), firstArgOrEmpty)
 }
 func VersionMandatoryArg(cmd *cobra.Command, args []string) error {
 	if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
@@ -48,7 +51,58 @@ func VersionMandatoryArg(cmd *cobra.Command, args []string) error {
 	if firstArgOrEmpty == "" {
 		return nil
 	}
-	return domain.ValidateVersion(firstArgOrEmpty)
+	return validateVersionSelector(commandPlugin(cmd), firstArgOrEmpty)
+}
+
+func SoftwareAndVersionMandatoryArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+		return err
+	}
+	plugin, _ := FindPlugin(args[0])
+	return validateVersionSelector(plugin, args[1])
+}
+
+// commandPlugin finds plugin of its subcommand like install, nil if the command does not belong to a plugin
+func commandPlugin(cmd *cobra.Command) domain.Plugin {
+	if !cmd.HasParent() {
+		return nil
+	}
+	return domain.GetPlugin(cmd.Parent().Name())
+}
+
+// validateVersionSelector accepts channels of the plugin, e.g. lts or eap, and user-defined aliases besides versions
+func validateVersionSelector(plugin domain.Plugin, arg string) error {
+	if domain.IsPluginChannel(plugin, arg) || software.IsAlias(arg) {
+		return nil
+	}
+	return domain.ValidateVersion(arg)
+}
+
+// QualifyVersionArg qualifies version argument of command with distribution chosen by flags,
+// e.g. java 21 with --vendor tem --package jre becomes 21-tem-jre
+func QualifyVersionArg(command *cobra.Command, name string, options func() map[string]string) {
+	run := command.Run
+	command.Run = func(cmd *cobra.Command, args []string) {
+		selector, err := software.QualifySelector(domain.GetPlugin(name), FirstOrEmpty(args), options())
+		if err != nil {
+			console.Fatal(err)
+		}
+		run(cmd, []string{selector})
+	}
+}
+
+// FindPlugin finds registered plugin by its name or by one of the aliases of its command
+func FindPlugin(nameOrAlias string) (domain.Plugin, error) {
+	for _, command := range RootCmd.Commands() {
+		if command.Name() != nameOrAlias && !command.HasAlias(nameOrAlias) {
+			continue
+		}
+		plugin := domain.GetPlugin(command.Name())
+		if plugin != nil {
+			return plugin, nil
+		}
+	}
+	return nil, errors.New("Unknown software: " + nameOrAlias)
 }
 
 func FirstOrEmpty(args []string) string {
@@ -0,0 +1,50 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"github.com/spf13/viper"
+)
+
+// LoadAliases reads user defined names of versions of the software, e.g. work-java -> 17.0.9
+func LoadAliases(name string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	if !viper.IsSet(name + AliasesSuffix) {
+		return aliases, nil
+	}
+	err := json.Unmarshal([]byte(viper.GetString(name+AliasesSuffix)), &aliases)
+	if err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func StoreAliases(name string, aliases map[string]string) error {
+	content, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+	viper.Set(name+AliasesSuffix, string(content))
+	return viper.WriteConfig()
+}
@@ -34,9 +34,16 @@ import (
 
 const HomeConfigDir = ".soft-ver-man"
 const RcFile = ".svmmainrc"
+const ShellFunctionsRcFile = ".svmshellrc"
+const PluginsDir = "plugins"
+const PluginsInfoCacheFile = ".plugins-info.json"
 const SoftwareDownloadDirKey = "software-directory-download"
 const SoftwareDirKey = "software-directory"
+const ShimsKey = "shims"
+const LaunchersKey = "launchers"
 const InstalledPackagesSuffix = "-installed-packages"
+const AliasesSuffix = "-aliases"
+const VersionGranularitySuffix = "-version-granularity"
 
 type Config struct {
 	SoftwareDownloadDir string
@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package config
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/spf13/viper"
+)
+
+// VersionGranularity returns granularity of versioned variables set by user, or the default one of the plugin
+func VersionGranularity(info domain.PluginInfo) domain.VersionGranularity {
+	if !viper.IsSet(info.Name + VersionGranularitySuffix) {
+		return info.VersionGranularity
+	}
+	granularity, err := domain.ParseVersionGranularity(viper.GetString(info.Name + VersionGranularitySuffix))
+	if err != nil {
+		return info.VersionGranularity
+	}
+	return granularity
+}
+
+func StoreVersionGranularity(name string, granularity domain.VersionGranularity) error {
+	viper.Set(name+VersionGranularitySuffix, string(granularity))
+	return viper.WriteConfig()
+}
@@ -25,54 +25,64 @@ package config
 import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
-	"github.com/pkk82/soft-ver-man/util/console"
 	"github.com/spf13/viper"
 	"os"
 	"text/tabwriter"
 )
 
-func DisplayAllInstalledPackages() {
+func DisplayAllInstalledPackages() error {
 
 	plugins := domain.GetPlugins()
 	for _, plugin := range plugins {
 		fmt.Println()
-		fmt.Println("Installed packages for: ", plugin.Name)
-		DisplayInstalledPackages(plugin.Name)
+		fmt.Println("Installed packages for: ", plugin.Info().Name)
+		err := DisplayInstalledPackages(plugin.Info().Name)
+		if err != nil {
+			return err
+		}
 	}
-
+	return nil
 }
 
-func DisplayInstalledPackages(name string) {
+func DisplayInstalledPackages(name string) error {
 
 	installedPackages, err := LoadInstalledPackages(name)
 	if err != nil {
-		console.Fatal(err)
+		return err
+	}
+
+	// vendor and features are shown only for software built by several vendors or in several flavours
+	flavoured := false
+	for _, item := range installedPackages.Items {
+		flavoured = flavoured || item.Vendor != "" || item.Features != ""
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', tabwriter.Debug)
 	if len(installedPackages.Items) == 0 {
 		_, err = fmt.Fprintln(w, "There are no installed packages")
+	} else if flavoured {
+		_, err = fmt.Fprintln(w, "Version\t Main\t Vendor\t Features\t Path")
 	} else {
 		_, err = fmt.Fprintln(w, "Version\t Main\t Path")
 	}
 	if err != nil {
-		console.Fatal(err)
+		return err
 	}
 	for _, item := range installedPackages.Items {
 		main := ""
 		if item.Main {
 			main = " Yes"
 		}
-		_, err = fmt.Fprintf(w, "%s\t %s\t %s\n", item.Version.Value, main, item.Path)
+		if flavoured {
+			_, err = fmt.Fprintf(w, "%s\t %s\t %s\t %s\t %s\n", item.Version.Value, main, item.Vendor, item.Features, item.Path)
+		} else {
+			_, err = fmt.Fprintf(w, "%s\t %s\t %s\n", item.Version.Value, main, item.Path)
+		}
 		if err != nil {
-			console.Fatal(err)
+			return err
 		}
 	}
-	err = w.Flush()
-	if err != nil {
-		console.Fatal(err)
-	}
-
+	return w.Flush()
 }
 
 func LoadAllInstalledPackages() ([]domain.InstalledPackages, error) {
@@ -80,8 +90,8 @@ func LoadAllInstalledPackages() ([]domain.InstalledPackages, error) {
 
 	plugins := domain.GetPlugins()
 	for _, plugin := range plugins {
-		json := readInstalledPackagesFromConfig(plugin.Name)
-		packages, err := domain.DeserializeInstalledPackages(plugin.Name, json)
+		json := readInstalledPackagesFromConfig(plugin.Info().Name)
+		packages, err := domain.DeserializeInstalledPackages(plugin.Info().Name, json)
 		if err != nil {
 			return nil, err
 		}
@@ -107,7 +117,7 @@ func StoreInstalledPackages(packages domain.InstalledPackages) error {
 		return err
 	}
 
-	err = writeInstalledPackagesToConfig(packages.Plugin.Name, installedPackages)
+	err = writeInstalledPackagesToConfig(packages.Plugin.Info().Name, installedPackages)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/spf13/viper"
+)
+
+// IsLaunchersRegistryInitialized tells whether launchers have been registered ever, launchers created before
+// the registry existed are registered on first refresh
+func IsLaunchersRegistryInitialized() bool {
+	return viper.IsSet(LaunchersKey)
+}
+
+func LoadLaunchers() ([]domain.RegisteredLauncher, error) {
+	launchers := make([]domain.RegisteredLauncher, 0)
+	if !viper.IsSet(LaunchersKey) {
+		return launchers, nil
+	}
+	err := json.Unmarshal([]byte(viper.GetString(LaunchersKey)), &launchers)
+	if err != nil {
+		return nil, err
+	}
+	return launchers, nil
+}
+
+func StoreLaunchers(launchers []domain.RegisteredLauncher) error {
+	if launchers == nil {
+		launchers = make([]domain.RegisteredLauncher, 0)
+	}
+	content, err := json.Marshal(launchers)
+	if err != nil {
+		return err
+	}
+	viper.Set(LaunchersKey, string(content))
+	return viper.WriteConfig()
+}
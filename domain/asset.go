@@ -22,10 +22,25 @@
 
 package domain
 
+import "strings"
+
 type Asset struct {
 	Version         string
 	Name            string
 	Url             string
 	Type            Type
 	ExtraProperties map[string]string
+	// Channels are named selectors the asset belongs to, e.g. lts, lts/iron, latest or stable
+	Channels []string
+	// Features of the package flavour, e.g. jre, javafx, crac or musl
+	Features []string
+}
+
+func (asset Asset) InChannel(channel string) bool {
+	for _, c := range asset.Channels {
+		if strings.EqualFold(c, channel) {
+			return true
+		}
+	}
+	return false
 }
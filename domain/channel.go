@@ -0,0 +1,95 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	ChannelLatest = "latest"
+	ChannelStable = "stable"
+	ChannelLts    = "lts"
+	// ChannelRelease and ChannelEap are provided by JetBrains IDEs only, eap stands for early access previews
+	ChannelRelease = "release"
+	ChannelEap     = "eap"
+)
+
+var channelPattern = regexp.MustCompile(`^(?i)(latest|stable|lts(/[a-z0-9_-]+)?)$`)
+
+// IsChannel tells whether selector names a channel common to all software instead of a version, e.g. latest, stable, lts or lts/iron
+func IsChannel(selector string) bool {
+	return channelPattern.MatchString(selector)
+}
+
+// IsPluginChannel tells whether selector names a common channel or a channel provided by the plugin, e.g. eap
+func IsPluginChannel(plugin Plugin, selector string) bool {
+	if IsChannel(selector) {
+		return true
+	}
+	provider, ok := plugin.(ChannelsProvider)
+	if !ok {
+		return false
+	}
+	for _, channel := range provider.Channels() {
+		if strings.EqualFold(channel, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGenericChannel tells whether channel can be resolved without plugin knowledge, latest and stable mean the highest GA release
+func IsGenericChannel(channel string) bool {
+	return strings.EqualFold(channel, ChannelLatest) || strings.EqualFold(channel, ChannelStable)
+}
+
+// SelectChannel narrows assets to the ones published in the channel,
+// latest and stable fall back to all assets if no asset is published in them
+func SelectChannel(channel string, assets []Asset) ([]Asset, error) {
+	selected := make([]Asset, 0)
+	for _, asset := range assets {
+		if asset.InChannel(channel) {
+			selected = append(selected, asset)
+		}
+	}
+	if len(selected) == 0 && IsGenericChannel(channel) {
+		return assets, nil
+	}
+	if len(selected) == 0 {
+		return nil, &VersionNotFoundError{Version: channel}
+	}
+	return selected, nil
+}
+
+// IsPreReleaseChannel tells whether channel assets are pre-releases only, e.g. eap, so they are selected without opt-in
+func IsPreReleaseChannel(assets []Asset) bool {
+	for _, asset := range assets {
+		version, err := NewVersion(asset.Version)
+		if err != nil || !version.IsPreRelease() {
+			return false
+		}
+	}
+	return len(assets) > 0
+}
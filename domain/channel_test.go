@@ -0,0 +1,136 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package domain_test
+
+import (
+	ver "github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func TestIsChannel(t *testing.T) {
+	tests := map[string]bool{
+		"latest":   true,
+		"stable":   true,
+		"lts":      true,
+		"lts/iron": true,
+		"LTS/Iron": true,
+		"release":  false,
+		"eap":      false,
+		"lts/":     false,
+		"18":       false,
+		"^18.2":    false,
+		"work":     false,
+	}
+	for selector, expected := range tests {
+		if actual := ver.IsChannel(selector); actual != expected {
+			t.Errorf("IsChannel(%v) = %v, expected: %v", selector, actual, expected)
+		}
+	}
+}
+
+type channelsPlugin struct {
+	ver.PluginInfo
+}
+
+func (channelsPlugin) Channels() []string {
+	return []string{ver.ChannelRelease, ver.ChannelEap}
+}
+
+func TestIsPluginChannel(t *testing.T) {
+	withChannels := channelsPlugin{ver.PluginInfo{Name: "goland"}}
+	withoutChannels := ver.PluginInfo{Name: "node"}
+	tests := []struct {
+		plugin   ver.Plugin
+		selector string
+		expected bool
+	}{
+		{plugin: withChannels, selector: "eap", expected: true},
+		{plugin: withChannels, selector: "Release", expected: true},
+		{plugin: withChannels, selector: "latest", expected: true},
+		{plugin: withChannels, selector: "2024.1", expected: false},
+		{plugin: withoutChannels, selector: "eap", expected: false},
+		{plugin: withoutChannels, selector: "lts", expected: true},
+		{plugin: nil, selector: "release", expected: false},
+	}
+	for _, tt := range tests {
+		if actual := ver.IsPluginChannel(tt.plugin, tt.selector); actual != tt.expected {
+			t.Errorf("IsPluginChannel(%v, %v) = %v, expected: %v", tt.plugin, tt.selector, actual, tt.expected)
+		}
+	}
+}
+
+func TestSelectChannel(t *testing.T) {
+	assets := []ver.Asset{
+		{Version: "21.6.1"},
+		{Version: "20.11.0", Channels: []string{"lts", "lts/iron"}},
+		{Version: "18.19.1", Channels: []string{"lts", "lts/hydrogen"}},
+	}
+	tests := []struct {
+		channel  string
+		expected []string
+		wantErr  bool
+	}{
+		{channel: "lts", expected: []string{"20.11.0", "18.19.1"}},
+		{channel: "lts/hydrogen", expected: []string{"18.19.1"}},
+		{channel: "latest", expected: []string{"21.6.1", "20.11.0", "18.19.1"}},
+		{channel: "lts/gallium", wantErr: true},
+	}
+	for _, tt := range tests {
+		selected, err := ver.SelectChannel(tt.channel, assets)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SelectChannel(%v) error = %v, wantErr %v", tt.channel, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		actual := make([]string, len(selected))
+		for i, asset := range selected {
+			actual[i] = asset.Version
+		}
+		if !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("SelectChannel(%v) = %v, expected: %v", tt.channel, actual, tt.expected)
+		}
+	}
+}
+
+func TestIsPreReleaseChannel(t *testing.T) {
+	tests := []struct {
+		versions []string
+		expected bool
+	}{
+		{versions: []string{"2024.2.eap10180", "2024.1.rc14494"}, expected: true},
+		{versions: []string{"2024.2.eap10180", "2024.1"}, expected: false},
+		{versions: nil, expected: false},
+	}
+	for _, tt := range tests {
+		assets := make([]ver.Asset, len(tt.versions))
+		for i, version := range tt.versions {
+			assets[i] = ver.Asset{Version: version}
+		}
+		if actual := ver.IsPreReleaseChannel(assets); actual != tt.expected {
+			t.Errorf("IsPreReleaseChannel(%v) = %v, expected: %v", tt.versions, actual, tt.expected)
+		}
+	}
+}
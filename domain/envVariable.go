@@ -39,6 +39,23 @@ func (v EnvVariable) ToExport() string {
 	return fmt.Sprintf("export %s", v.toString())
 }
 
+// ToEvalExport is like ToExport, but literal parts are single-quoted,
+// it is meant for lines evaluated by the shell on the fly, e.g. by svm use or the hook
+func (v EnvVariable) ToEvalExport() string {
+	if v.PrefixVariable == nil {
+		return fmt.Sprintf("export %v=%v", v.Name, ShellQuote(v.SuffixValue))
+	}
+	if v.SuffixValue == "" {
+		return fmt.Sprintf("export %v=\"$%v\"", v.Name, v.PrefixVariable.Name)
+	}
+	return fmt.Sprintf("export %v=\"$%v\"%v", v.Name, v.PrefixVariable.Name, ShellQuote(string(os.PathSeparator)+v.SuffixValue))
+}
+
+// ShellQuote quotes value in single quotes, so that the shell takes it literally, e.g. $ or ` in a PATH entry
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 func (v EnvVariable) ToEnv() string {
 	return fmt.Sprintf("env %s", v.toString())
 }
@@ -76,24 +93,111 @@ func (envVariables EnvVariables) ToExport() []string {
 	}
 
 	if envVariables.MainVariable != nil {
-
-		var executableRelativePath string
-		if envVariables.ExecutableRelativePath == "" ||
-			strings.HasPrefix(envVariables.ExecutableRelativePath, string(os.PathSeparator)) {
-			executableRelativePath = envVariables.ExecutableRelativePath
-		} else {
-			executableRelativePath = path.Join(string(os.PathSeparator), envVariables.ExecutableRelativePath)
-		}
-
 		lines[len(lines)-1] = fmt.Sprintf("export PATH=\"$%v%v%v$PATH\"",
 			mainVariable.Name,
-			executableRelativePath,
+			envVariables.executableRelativePath(),
 			string(os.PathListSeparator))
 
 	}
 	return lines
 }
 
+// ToEvalExport is like ToExport, but literal parts are single-quoted
+func (envVariables EnvVariables) ToEvalExport() []string {
+	lines := make([]string, 0, len(envVariables.Variables)+1)
+	for _, envVariable := range envVariables.Variables {
+		lines = append(lines, envVariable.ToEvalExport())
+	}
+	if envVariables.MainVariable != nil {
+		lines = append(lines, fmt.Sprintf("export PATH=\"$%v\"%v\"$PATH\"",
+			envVariables.MainVariable.Name,
+			ShellQuote(envVariables.executableRelativePath()+string(os.PathListSeparator))))
+	}
+	return lines
+}
+
+func (envVariables EnvVariables) executableRelativePath() string {
+	if envVariables.ExecutableRelativePath == "" ||
+		strings.HasPrefix(envVariables.ExecutableRelativePath, string(os.PathSeparator)) {
+		return envVariables.ExecutableRelativePath
+	}
+	return path.Join(string(os.PathSeparator), envVariables.ExecutableRelativePath)
+}
+
+// DirectEnvVariables points main variable of the plugin straight to the directory of installed package
+func DirectEnvVariables(plugin Plugin, installedPackage InstalledPackage) EnvVariables {
+	mainVariable := EnvVariable{
+		Name:        homeVariable(plugin),
+		SuffixValue: installedPackage.Path,
+	}
+	return EnvVariables{
+		Variables:              []EnvVariable{mainVariable},
+		MainVariable:           &mainVariable,
+		ExecutableRelativePath: plugin.Info().ExecutableRelativePath,
+	}
+}
+
+// StripPathEntries removes from PATH-like value all entries located in the given directory
+func StripPathEntries(pathValue, dir string) string {
+	if pathValue == "" {
+		return ""
+	}
+	dirPrefix := strings.TrimSuffix(dir, string(os.PathSeparator)) + string(os.PathSeparator)
+	entries := make([]string, 0)
+	for _, entry := range strings.Split(pathValue, string(os.PathListSeparator)) {
+		if entry+string(os.PathSeparator) == dirPrefix || strings.HasPrefix(entry, dirPrefix) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, string(os.PathListSeparator))
+}
+
+// ToEnviron applies resolved variables to key=value entries of the environment, executable directory is prepended to PATH
+func (envVariables EnvVariables) ToEnviron(environ []string) ([]string, error) {
+	values := make(map[string]string)
+	names := make([]string, 0)
+	for _, variable := range envVariables.Variables {
+		if variable.PrefixVariable != nil {
+			return nil, fmt.Errorf("variable %v is not resolved", variable.Name)
+		}
+		values[variable.Name] = variable.SuffixValue
+		names = append(names, variable.Name)
+	}
+
+	result := make([]string, 0, len(environ)+len(names)+1)
+	pathValue := ""
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		if name == "PATH" {
+			pathValue = value
+			continue
+		}
+		if _, ok := values[name]; !ok {
+			result = append(result, entry)
+		}
+	}
+	for _, name := range names {
+		result = append(result, name+"="+values[name])
+	}
+
+	if envVariables.MainVariable != nil {
+		if envVariables.MainVariable.PrefixVariable != nil {
+			return nil, fmt.Errorf("variable %v is not resolved", envVariables.MainVariable.Name)
+		}
+		executableDir := path.Join(envVariables.MainVariable.SuffixValue, envVariables.ExecutableRelativePath)
+		if pathValue == "" {
+			pathValue = executableDir
+		} else {
+			pathValue = executableDir + string(os.PathListSeparator) + pathValue
+		}
+	}
+	if pathValue != "" {
+		result = append(result, "PATH="+pathValue)
+	}
+	return result, nil
+}
+
 func (envVariables EnvVariables) Resolve(extraEnvVariables []EnvVariable) (EnvVariables, error) {
 
 	envVariablesByName := make(map[string]EnvVariable)
@@ -212,3 +212,128 @@ func Test_EvnVariables_extractToHere(t *testing.T) {
 		})
 	}
 }
+
+func Test_DirectEnvVariables(t *testing.T) {
+	plugin := PluginInfo{Name: "node", EnvNamePrefix: "NODE", EnvNameSuffix: "_HOME", ExecutableRelativePath: "bin"}
+	installedPackage := InstalledPackage{Version: Ver("v20.1.3", t), Path: "/home/user/pf/node/node-v20.1.3-linux-x64"}
+
+	got := DirectEnvVariables(plugin, installedPackage).ToExport()
+	want := []string{
+		"export NODE_HOME=\"/home/user/pf/node/node-v20.1.3-linux-x64\"",
+		"export PATH=\"$NODE_HOME/bin:$PATH\"",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DirectEnvVariables().ToExport() = %v, want %v", got, want)
+	}
+}
+
+func Test_StripPathEntries(t *testing.T) {
+	tests := []struct {
+		name      string
+		pathValue string
+		dir       string
+		want      string
+	}{
+		{
+			name:      "empty",
+			pathValue: "",
+			dir:       "/home/user/pf/node",
+			want:      "",
+		},
+		{
+			name:      "nothing to strip",
+			pathValue: "/usr/local/bin:/usr/bin",
+			dir:       "/home/user/pf/node",
+			want:      "/usr/local/bin:/usr/bin",
+		},
+		{
+			name:      "strip all entries of plugin",
+			pathValue: "/home/user/pf/node/node-v18.1.0-linux-x64/bin:/home/user/pf/node/node-v20.1.3-linux-x64/bin:/usr/bin",
+			dir:       "/home/user/pf/node",
+			want:      "/usr/bin",
+		},
+		{
+			name:      "keep entries of plugins with common prefix",
+			pathValue: "/home/user/pf/nodejs/bin:/home/user/pf/node/node-v20.1.3-linux-x64/bin:/usr/bin",
+			dir:       "/home/user/pf/node/",
+			want:      "/home/user/pf/nodejs/bin:/usr/bin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripPathEntries(tt.pathValue, tt.dir); got != tt.want {
+				t.Errorf("StripPathEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_EnvVariables_ToEnviron(t *testing.T) {
+	plugin := PluginInfo{Name: "node", EnvNamePrefix: "NODE", EnvNameSuffix: "_HOME", ExecutableRelativePath: "bin"}
+	installedPackage := InstalledPackage{Version: Ver("v20.1.3", t), Path: "/pf/node/node-v20.1.3"}
+
+	tests := []struct {
+		name         string
+		envVariables EnvVariables
+		environ      []string
+		want         []string
+		wantErr      bool
+	}{
+		{
+			name:         "override variable and prepend path",
+			envVariables: DirectEnvVariables(plugin, installedPackage),
+			environ:      []string{"NODE_HOME=/old", "PATH=/usr/bin", "LANG=C"},
+			want:         []string{"LANG=C", "NODE_HOME=/pf/node/node-v20.1.3", "PATH=/pf/node/node-v20.1.3/bin:/usr/bin"},
+		},
+		{
+			name:         "no path",
+			envVariables: DirectEnvVariables(plugin, installedPackage),
+			environ:      []string{},
+			want:         []string{"NODE_HOME=/pf/node/node-v20.1.3", "PATH=/pf/node/node-v20.1.3/bin"},
+		},
+		{
+			name: "resolved variables without main variable",
+			envVariables: EnvVariables{
+				Variables: []EnvVariable{{Name: "NODE_20_HOME", SuffixValue: "/pf/node/node-v20.1.3"}},
+			},
+			environ: []string{"PATH=/usr/bin"},
+			want:    []string{"NODE_20_HOME=/pf/node/node-v20.1.3", "PATH=/usr/bin"},
+		},
+		{
+			name: "unresolved variable",
+			envVariables: EnvVariables{
+				Variables: []EnvVariable{{Name: "NODE_HOME", PrefixVariable: &EnvVariable{Name: "NODE_20_HOME"}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.envVariables.ToEnviron(tt.environ)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToEnviron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToEnviron() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ToEvalExport(t *testing.T) {
+	dirVariable := EnvVariable{Name: "SVM_SOFT_GO_DIR", SuffixValue: "/home/user/pf/go"}
+	goRoot := EnvVariable{Name: "GO_1_14_ROOT", PrefixVariable: &dirVariable, SuffixValue: "go1.14.15 'x'"}
+	mainVariable := EnvVariable{Name: "GOROOT", PrefixVariable: &goRoot}
+	envVariables := EnvVariables{Variables: []EnvVariable{dirVariable, goRoot, mainVariable}, MainVariable: &mainVariable, ExecutableRelativePath: "bin"}
+
+	got := envVariables.ToEvalExport()
+	want := []string{
+		"export SVM_SOFT_GO_DIR='/home/user/pf/go'",
+		"export GO_1_14_ROOT=\"$SVM_SOFT_GO_DIR\"'/go1.14.15 '\\''x'\\'''",
+		"export GOROOT=\"$GO_1_14_ROOT\"",
+		"export PATH=\"$GOROOT\"'/bin:'\"$PATH\"",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToEvalExport() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package domain
+
+import "strings"
+
+// UnknownSoftwareError is returned when no plugin is registered for the name
+type UnknownSoftwareError struct {
+	Name string
+}
+
+func (e *UnknownSoftwareError) Error() string {
+	return "unknown software: " + e.Name
+}
+
+// UnsupportedError is returned when plugin lacks capability needed for the operation
+type UnsupportedError struct {
+	Name      string
+	Operation string
+}
+
+func (e *UnsupportedError) Error() string {
+	return e.Operation + " of " + e.Name + " is not supported"
+}
+
+type NotInstalledError struct {
+	Name       string
+	Version    string
+	Candidates []string
+}
+
+func (e *NotInstalledError) Error() string {
+	if e.Version == "" {
+		return "No packages installed for " + e.Name
+	}
+	return "Version " + e.Version + " is not installed" + candidatesSuffix(e.Candidates)
+}
+
+type AlreadyInstalledError struct {
+	Name    string
+	Version string
+}
+
+func (e *AlreadyInstalledError) Error() string {
+	return "Version " + e.Version + " is already installed"
+}
+
+type VersionNotFoundError struct {
+	Version    string
+	Candidates []string
+}
+
+func (e *VersionNotFoundError) Error() string {
+	return "No version found for: " + e.Version + candidatesSuffix(e.Candidates)
+}
+
+func candidatesSuffix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	return " (closest candidates: " + strings.Join(candidates, ", ") + ")"
+}
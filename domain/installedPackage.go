@@ -41,13 +41,26 @@ type InstalledPackage struct {
 	Path        string
 	InstalledOn int64
 	Main        bool
+	// Vendor of software built by several vendors, e.g. tem or zulu for JDK
+	Vendor string
+	// Features of the package flavour separated by comma, e.g. jre,javafx,musl
+	Features string
 }
 
+// RoundVersion keeps components of the granularity, missing ones are taken as 0, e.g. 2024.1 becomes 2024.1.0 for patch
 func (ip *InstalledPackage) RoundVersion(versionGranularity VersionGranularity) (Version, error) {
+	distribution := ""
+	if ip.Version.Distribution() != "" {
+		distribution = "-" + ip.Version.Distribution()
+	}
 	if versionGranularity == VersionGranularityMajor {
-		return NewVersion(fmt.Sprintf("%d", ip.Version.Major()))
+		return NewVersion(fmt.Sprintf("%d%v", ip.Version.Major(), distribution))
 	} else if versionGranularity == VersionGranularityMinor {
-		return NewVersion(fmt.Sprintf("%d.%d", ip.Version.Major(), ip.Version.Minor()))
+		return NewVersion(fmt.Sprintf("%d.%d%v", ip.Version.Major(), zeroIfMissing(ip.Version.Minor()), distribution))
+	} else if versionGranularity == VersionGranularityPatch {
+		return NewVersion(fmt.Sprintf("%d.%d.%d%v", ip.Version.Major(), zeroIfMissing(ip.Version.Minor()), zeroIfMissing(ip.Version.Patch()), distribution))
+	} else if versionGranularity == VersionGranularityFull {
+		return ip.Version, nil
 	}
 	return Version{}, errors.New(string(versionGranularity + " is not supported"))
 }
@@ -61,6 +61,33 @@ func Test_InstalledPackage_RoundVersion(t *testing.T) {
 			wantErr: false,
 			want:    Ver("20.1", t),
 		},
+		{
+			name: "patch",
+			args: args{
+				installedPackage: installedPackage,
+				granularity:      VersionGranularityPatch,
+			},
+			wantErr: false,
+			want:    Ver("20.1.3", t),
+		},
+		{
+			name: "full",
+			args: args{
+				installedPackage: installedPackage,
+				granularity:      VersionGranularityFull,
+			},
+			wantErr: false,
+			want:    Ver("v20.1.3", t),
+		},
+		{
+			name: "patch of version without patch",
+			args: args{
+				installedPackage: InstalledPackage{Version: Ver("2024.1", t)},
+				granularity:      VersionGranularityPatch,
+			},
+			wantErr: false,
+			want:    Ver("2024.1.0", t),
+		},
 		{
 			name: "minor",
 			args: args{
@@ -26,11 +26,14 @@ import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/util/collections"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
+var nonVariableChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
 type InstalledPackages struct {
 	Plugin Plugin
 	Items  []InstalledPackage
@@ -56,6 +59,31 @@ func (installedPackages *InstalledPackages) Add(installedPackage InstalledPackag
 	installedPackages.Items = append(installedPackages.Items, installedPackage)
 }
 
+func (installedPackages *InstalledPackages) SetMain(version Version) error {
+	if !installedPackages.IsInstalled(version) {
+		return &NotInstalledError{Name: installedPackages.name(), Version: version.Value}
+	}
+	for i, item := range installedPackages.Items {
+		installedPackages.Items[i].Main = item.Version == version
+	}
+	return nil
+}
+
+func (installedPackages *InstalledPackages) name() string {
+	if installedPackages.Plugin == nil {
+		return ""
+	}
+	return installedPackages.Plugin.Info().Name
+}
+
+func (installedPackages *InstalledPackages) Versions() []string {
+	versions := make([]string, len(installedPackages.Items))
+	for i, item := range installedPackages.Items {
+		versions[i] = item.Version.Value
+	}
+	return versions
+}
+
 func (installedPackages *InstalledPackages) RemoveByVersion(version Version) *InstalledPackage {
 	newItems := make([]InstalledPackage, 0)
 	var itemToRemove *InstalledPackage = nil
@@ -100,17 +128,22 @@ func (installedPackages *InstalledPackages) FoundMain() (*InstalledPackage, erro
 
 }
 
-func (installedPackages *InstalledPackages) PrepareEnvVariables(plugin Plugin) (EnvVariables, error) {
+// PrepareEnvVariables prepares variable per rounded version, granularity is plugin's one unless overridden by user
+func (installedPackages *InstalledPackages) PrepareEnvVariables(plugin Plugin, granularity VersionGranularity) (EnvVariables, error) {
 
 	var items = make([]InstalledPackage, len(installedPackages.Items))
 	copy(items, installedPackages.Items)
 
-	classifier := func(ip InstalledPackage) Version {
-		version, err := ip.RoundVersion(plugin.VersionGranularity)
+	roundedVersionsOfItems := make(map[Version]Version, len(items))
+	for _, item := range items {
+		version, err := item.RoundVersion(granularity)
 		if err != nil {
-			panic(err)
+			return EnvVariables{}, err
 		}
-		return version
+		roundedVersionsOfItems[item.Version] = version
+	}
+	classifier := func(ip InstalledPackage) Version {
+		return roundedVersionsOfItems[ip.Version]
 	}
 
 	packagesByRoundedVersion := collections.GroupByAndCollect(items, classifier, transformer)
@@ -124,9 +157,9 @@ func (installedPackages *InstalledPackages) PrepareEnvVariables(plugin Plugin) (
 	}
 
 	refVariable := EnvVariable{
-		Name:           fmt.Sprintf(VarNameSvmSoftPackageDirTemplate, plugin.EnvNamePrefix),
+		Name:           fmt.Sprintf(VarNameSvmSoftPackageDirTemplate, plugin.Info().EnvNamePrefix),
 		PrefixVariable: &svmSoftDirVariable,
-		SuffixValue:    plugin.Name,
+		SuffixValue:    plugin.Info().Name,
 	}
 
 	envVariables := make([]EnvVariable, 0)
@@ -149,7 +182,7 @@ func (installedPackages *InstalledPackages) PrepareEnvVariables(plugin Plugin) (
 		envVariable := EnvVariable{
 			PrefixVariable: &refVariable,
 			SuffixValue:    dirName,
-			Name:           versionedHomeVariable(plugin, roundedVersion),
+			Name:           versionedHomeVariable(plugin, granularity, roundedVersion),
 		}
 
 		for _, p := range packages.Values {
@@ -169,7 +202,7 @@ func (installedPackages *InstalledPackages) PrepareEnvVariables(plugin Plugin) (
 	return EnvVariables{
 		Variables:              envVariables,
 		MainVariable:           &mainVariable,
-		ExecutableRelativePath: plugin.ExecutableRelativePath,
+		ExecutableRelativePath: plugin.Info().ExecutableRelativePath,
 	}, nil
 
 }
@@ -180,22 +213,28 @@ func transformer(ips []InstalledPackage) collections.SortedList[InstalledPackage
 	})
 }
 
-func versionedHomeVariable(plugin Plugin, version Version) string {
+func versionedHomeVariable(plugin Plugin, granularity VersionGranularity, version Version) string {
 	var v string
-	switch plugin.VersionGranularity {
+	switch granularity {
 	case VersionGranularityMajor:
 		v = strconv.Itoa(version.Major())
 	case VersionGranularityMinor:
-		v = fmt.Sprintf("%v_%v", version.Major(), version.Minor())
-
+		v = fmt.Sprintf("%v_%v", version.Major(), zeroIfMissing(version.Minor()))
+	case VersionGranularityPatch:
+		v = fmt.Sprintf("%v_%v_%v", version.Major(), zeroIfMissing(version.Minor()), zeroIfMissing(version.Patch()))
+	case VersionGranularityFull:
+		v = strings.ToUpper(nonVariableChars.ReplaceAllString(strings.TrimPrefix(version.Value, "v"), "_"))
+	}
+	if version.Distribution() != "" && granularity != VersionGranularityFull {
+		v += "_" + strings.ToUpper(nonVariableChars.ReplaceAllString(version.Distribution(), "_"))
 	}
-	envNameSuffix := plugin.EnvNameSuffix
+	envNameSuffix := plugin.Info().EnvNameSuffix
 	if !strings.HasPrefix(envNameSuffix, "_") {
 		envNameSuffix = "_" + envNameSuffix
 	}
-	return fmt.Sprintf("%v_%v%v", plugin.EnvNamePrefix, v, envNameSuffix)
+	return fmt.Sprintf("%v_%v%v", plugin.Info().EnvNamePrefix, v, envNameSuffix)
 }
 
 func homeVariable(plugin Plugin) string {
-	return fmt.Sprintf("%v%v", plugin.EnvNamePrefix, plugin.EnvNameSuffix)
+	return fmt.Sprintf("%v%v", plugin.Info().EnvNamePrefix, plugin.Info().EnvNameSuffix)
 }
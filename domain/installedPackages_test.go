@@ -36,13 +36,13 @@ func Test_InstalledPackages_IsInstalled(t *testing.T) {
 	}{
 		{
 			name:              "empty - false",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{}},
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
 			version:           Ver("v20.1.4", t),
 			want:              false,
 		},
 		{
 			name: "one element - true",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -54,7 +54,7 @@ func Test_InstalledPackages_IsInstalled(t *testing.T) {
 			want:    true,
 		}, {
 			name: "one element -  false",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -89,7 +89,7 @@ func Test_InstalledPackages_Add(t *testing.T) {
 	}{
 		{
 			name:              "empty",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{}},
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
 			newPackage: InstalledPackage{
 				Version:     Ver("v20.1.4", t),
 				Path:        "/home/user/pf/node/node-v20.1.4-linux-x64",
@@ -107,7 +107,7 @@ func Test_InstalledPackages_Add(t *testing.T) {
 		},
 		{
 			name:              "empty - add main",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{}},
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
 			newPackage: InstalledPackage{
 				Version:     Ver("v20.1.4", t),
 				Path:        "/home/user/pf/node/node-v20.1.4-linux-x64",
@@ -125,7 +125,7 @@ func Test_InstalledPackages_Add(t *testing.T) {
 		},
 		{
 			name: "with-one-item ",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -152,7 +152,7 @@ func Test_InstalledPackages_Add(t *testing.T) {
 			}},
 		}, {
 			name: "with-one-item - add main",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -202,13 +202,13 @@ func Test_InstalledPackages_Remove(t *testing.T) {
 	}{
 		{
 			name:              "empty",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{}},
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
 			want:              []InstalledPackage{},
 			wantRemoved:       nil,
 		},
 		{
 			name: "with-one-item",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -226,7 +226,7 @@ func Test_InstalledPackages_Remove(t *testing.T) {
 		},
 		{
 			name: "with-two-items",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -271,6 +271,69 @@ func Test_InstalledPackages_Remove(t *testing.T) {
 	}
 }
 
+func Test_InstalledPackages_SetMain(t *testing.T) {
+
+	tests := []struct {
+		name              string
+		installedPackages InstalledPackages
+		version           Version
+		want              []InstalledPackage
+		wantErr           bool
+	}{
+		{
+			name:              "empty",
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
+			version:           Ver("v20.1.3", t),
+			want:              []InstalledPackage{},
+			wantErr:           true,
+		},
+		{
+			name: "switch main",
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
+				{
+					Version:     Ver("v20.1.3", t),
+					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
+					Main:        true,
+					InstalledOn: 1689017267000,
+				},
+				{
+					Version:     Ver("v18.1.0", t),
+					Path:        "/home/user/pf/node/node-v18.1.0-linux-x64",
+					Main:        false,
+					InstalledOn: 1689017268000,
+				},
+			}},
+			version: Ver("v18.1.0", t),
+			want: []InstalledPackage{
+				{
+					Version:     Ver("v20.1.3", t),
+					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
+					Main:        false,
+					InstalledOn: 1689017267000,
+				},
+				{
+					Version:     Ver("v18.1.0", t),
+					Path:        "/home/user/pf/node/node-v18.1.0-linux-x64",
+					Main:        true,
+					InstalledOn: 1689017268000,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.installedPackages.SetMain(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetMain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.installedPackages.Items, tt.want) {
+				t.Errorf("SetMain().Items = %v, want %v", tt.installedPackages.Items, tt.want)
+			}
+		})
+	}
+}
+
 func Test_InstalledPackages_FoundMain(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -280,13 +343,13 @@ func Test_InstalledPackages_FoundMain(t *testing.T) {
 	}{
 		{
 			name:    "empty",
-			arg:     InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{}},
+			arg:     InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{}},
 			wantErr: true,
 			want:    nil,
 		},
 		{
 			name: "one main",
-			arg: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			arg: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
@@ -304,7 +367,7 @@ func Test_InstalledPackages_FoundMain(t *testing.T) {
 		},
 		{
 			name: "many mains",
-			arg: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			arg: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.1", t),
 					Path:        "/home/user/pf/node/node-v20.1.1-linux-x64",
@@ -338,7 +401,7 @@ func Test_InstalledPackages_FoundMain(t *testing.T) {
 			},
 		}, {
 			name: "no mains",
-			arg: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			arg: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.1", t),
 					Path:        "/home/user/pf/node/node-v20.1.1-linux-x64",
@@ -388,3 +451,31 @@ func Test_InstalledPackages_FoundMain(t *testing.T) {
 		})
 	}
 }
+
+func Test_versionedHomeVariable(t *testing.T) {
+	plugin := PluginInfo{Name: "java", EnvNamePrefix: "JAVA", EnvNameSuffix: "_HOME"}
+	tests := []struct {
+		granularity VersionGranularity
+		version     string
+		want        string
+	}{
+		{granularity: VersionGranularityMajor, version: "17.0.9", want: "JAVA_17_HOME"},
+		{granularity: VersionGranularityMinor, version: "17.0.9", want: "JAVA_17_0_HOME"},
+		{granularity: VersionGranularityPatch, version: "17.0.9", want: "JAVA_17_0_9_HOME"},
+		{granularity: VersionGranularityFull, version: "17.0.9+9", want: "JAVA_17_0_9_9_HOME"},
+		{granularity: VersionGranularityFull, version: "v21-ea", want: "JAVA_21_EA_HOME"},
+		{granularity: VersionGranularityMajor, version: "21.0.2-tem", want: "JAVA_21_TEM_HOME"},
+		{granularity: VersionGranularityPatch, version: "21.0.2-tem", want: "JAVA_21_0_2_TEM_HOME"},
+		{granularity: VersionGranularityFull, version: "21.0.2-tem", want: "JAVA_21_0_2_TEM_HOME"},
+		{granularity: VersionGranularityMajor, version: "21.0.2-tem-jre", want: "JAVA_21_TEM_JRE_HOME"},
+		{granularity: VersionGranularityPatch, version: "2024.1", want: "JAVA_2024_1_0_HOME"},
+		{granularity: VersionGranularityMinor, version: "21", want: "JAVA_21_0_HOME"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.granularity)+" "+tt.version, func(t *testing.T) {
+			if got := versionedHomeVariable(plugin, tt.granularity, Ver(tt.version, t)); got != tt.want {
+				t.Errorf("versionedHomeVariable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -37,12 +37,18 @@ type installedPackageJsonModel struct {
 	Path        string `json:"path"`
 	InstalledOn int64  `json:"installedOn"`
 	Main        bool   `json:"main"`
+	Vendor      string `json:"vendor,omitempty"`
+	Features    string `json:"features,omitempty"`
 }
 
 func (installedPackages *InstalledPackages) SerializeInstalledPackages() (string, error) {
 	jsonModel := mapToJsonModel(installedPackages.Items)
+	name := ""
+	if installedPackages.Plugin != nil {
+		name = installedPackages.Plugin.Info().Name
+	}
 	installedPackagesJsonModel := installedPackagesJsonModel{
-		Name:  installedPackages.Plugin.Name,
+		Name:  name,
 		Items: jsonModel,
 	}
 	serialized, err := json.Marshal(installedPackagesJsonModel)
@@ -84,6 +90,8 @@ func mapFromJsonModel(items []installedPackageJsonModel) ([]InstalledPackage, er
 			Path:        item.Path,
 			InstalledOn: item.InstalledOn,
 			Main:        item.Main,
+			Vendor:      item.Vendor,
+			Features:    item.Features,
 		})
 	}
 	return result, nil
@@ -97,6 +105,8 @@ func mapToJsonModel(installedPackages []InstalledPackage) []installedPackageJson
 			Path:        item.Path,
 			InstalledOn: item.InstalledOn,
 			Main:        item.Main,
+			Vendor:      item.Vendor,
+			Features:    item.Features,
 		}
 	}
 	return result
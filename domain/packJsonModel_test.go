@@ -101,13 +101,13 @@ func Test_InstalledPackages_Serialize(t *testing.T) {
 		},
 		{
 			name:              "name only",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}},
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}},
 			want:              `{"name":"node","items":[]}`,
 			wantErr:           false,
 		},
 		{
 			name: "domain",
-			installedPackages: InstalledPackages{Plugin: Plugin{Name: "node"}, Items: []InstalledPackage{
+			installedPackages: InstalledPackages{Plugin: PluginInfo{Name: "node"}, Items: []InstalledPackage{
 				{
 					Version:     Ver("v20.1.3", t),
 					Path:        "/home/user/pf/node/node-v20.1.3-linux-x64",
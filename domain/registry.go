@@ -23,7 +23,10 @@
 package domain
 
 import (
+	"context"
+	"fmt"
 	"sort"
+	"strings"
 )
 
 type VersionGranularity string
@@ -31,8 +34,20 @@ type VersionGranularity string
 const (
 	VersionGranularityMajor VersionGranularity = "MAJOR"
 	VersionGranularityMinor VersionGranularity = "MINOR"
+	VersionGranularityPatch VersionGranularity = "PATCH"
+	// VersionGranularityFull uses raw version, so every installed package gets its own variable
+	VersionGranularityFull VersionGranularity = "FULL"
 )
 
+func ParseVersionGranularity(value string) (VersionGranularity, error) {
+	granularity := VersionGranularity(strings.ToUpper(value))
+	switch granularity {
+	case VersionGranularityMajor, VersionGranularityMinor, VersionGranularityPatch, VersionGranularityFull:
+		return granularity, nil
+	}
+	return "", fmt.Errorf("unsupported version granularity: %v, use one of MAJOR, MINOR, PATCH, FULL", value)
+}
+
 type ExtractStrategy string
 
 // how to extract archive
@@ -47,27 +62,94 @@ const (
 	ReplaceCompressedDirWithArchiveName ExtractStrategy = "archive_replace"
 )
 
-type Plugin struct {
-	Name                        string
-	EnvNamePrefix               string
-	EnvNameSuffix               string
-	ExtraVariables              func(homeDir string) EnvVariables
-	ExecutableRelativePath      string
-	VersionGranularity          VersionGranularity
-	ExtractStrategy             ExtractStrategy
-	RawExecutableName           string
-	CalculateDownloadUrl        func(version Version, os, arch string) (string, Type)
-	CalculateDownloadedFileName func(asset Asset) string
-	PostInstall                 func(installedPackage InstalledPackage) error
-	PostUninstall               func(version Version) error
-	VerifyChecksum              func(asset Asset, fetchedPackage FetchedPackage) error
-	GetAvailableAssets          func() ([]Asset, error)
+// PluginInfo describes software, it is the core of every plugin
+type PluginInfo struct {
+	Name                   string
+	EnvNamePrefix          string
+	EnvNameSuffix          string
+	ExecutableRelativePath string
+	VersionGranularity     VersionGranularity
+	ExtractStrategy        ExtractStrategy
+	RawExecutableName      string
+}
+
+func (info PluginInfo) Info() PluginInfo {
+	return info
+}
+
+// Plugin is implemented by every supported software, optional capabilities are expressed by the interfaces below
+type Plugin interface {
+	Info() PluginInfo
+}
+
+// Lister lists assets available to download
+type Lister interface {
+	GetAvailableAssets(ctx context.Context) ([]Asset, error)
+}
+
+// Distributions is implemented by software built by several vendors or in several flavours, e.g. JDK,
+// versions of the default distribution are plain, versions of others are qualified like 21.0.2-tem or 21.0.2-tem-jre
+type Distributions interface {
+	DefaultDistribution() string
+	// Distribution composes qualifier from options given by flags or manifest, e.g. vendor: tem, package: jre
+	Distribution(options map[string]string) (string, error)
+	// SplitDistribution splits qualifier into vendor and features recorded with installed package, e.g. tem-jre into tem and jre
+	SplitDistribution(distribution string) (vendor string, features []string, err error)
+	GetDistributionAssets(ctx context.Context, distribution string) ([]Asset, error)
+}
+
+// ChannelsProvider is implemented by software publishing assets in channels of its own, e.g. eap of JetBrains IDEs,
+// the channels are not recognized for other software
+type ChannelsProvider interface {
+	Channels() []string
+}
+
+// DownloadUrlCalculator calculates download url of software that cannot be listed
+type DownloadUrlCalculator interface {
+	CalculateDownloadUrl(version Version, os, arch string) (string, Type, error)
+}
+
+// DownloadedFileNamer names downloaded file, asset name is used otherwise
+type DownloadedFileNamer interface {
+	CalculateDownloadedFileName(asset Asset) string
+}
+
+type ChecksumVerifier interface {
+	VerifyChecksum(ctx context.Context, asset Asset, fetchedPackage FetchedPackage) error
+}
+
+type InstallHook interface {
+	PostInstall(installedPackage InstalledPackage) error
+	PostUninstall(version Version) error
+}
+
+// Launcher creates (or overrides) desktop launcher of installed package with environment variables baked in,
+// returns path of the launcher or empty path if launchers are not supported on the OS,
+// launchers are kept in registry and regenerated whenever installed packages of any software change
+type Launcher interface {
+	CreateLauncher(installedPackage InstalledPackage, installedPackages InstalledPackages) (string, error)
+}
+
+type ExtraEnv interface {
+	ExtraVariables(homeDir string) EnvVariables
+}
+
+type ProjectVersionDetector interface {
+	DetectProjectVersion(dir string) (ProjectVersion, bool, error)
 }
 
 var mainRegistry = make(map[string]Plugin)
 
 func Register(plugin Plugin) {
-	mainRegistry[plugin.Name] = plugin
+	mainRegistry[plugin.Info().Name] = plugin
+}
+
+// DownloadedFileName names downloaded file of asset
+func DownloadedFileName(plugin Plugin, asset Asset) string {
+	if namer, ok := plugin.(DownloadedFileNamer); ok {
+		return namer.CalculateDownloadedFileName(asset)
+	}
+	return asset.Name
 }
 
 func GetPlugin(name string) Plugin {
@@ -80,7 +162,7 @@ func GetPlugins() []Plugin {
 		plugins = append(plugins, plugin)
 	}
 	sort.Slice(plugins, func(i, j int) bool {
-		return plugins[i].Name < plugins[j].Name
+		return plugins[i].Info().Name < plugins[j].Info().Name
 	})
 	return plugins
 }
@@ -30,8 +30,15 @@ type Version struct {
 	patch       int
 	buildString string
 	build       int
+	// preRelease is lower-cased qualifier like rc, beta, alpha, ea or eap, empty for GA release
+	preRelease       string
+	preReleaseNumber int
+	// distribution is qualifier of software built by several vendors or in several flavours, e.g. tem-jre in 21.0.2-tem-jre
+	distribution string
 }
 
+var preReleaseRanks = map[string]int{"alpha": 1, "beta": 2, "ea": 3, "eap": 3, "rc": 4}
+
 func NewVersion(version string) (Version, error) {
 	v, err := parseVersion(version)
 	if err != nil {
@@ -48,13 +55,51 @@ func (version Version) Minor() int {
 	return version.minor
 }
 
+func (version Version) Patch() int {
+	return version.patch
+}
+
+func (version Version) Distribution() string {
+	return version.distribution
+}
+
+func (version Version) IsPreRelease() bool {
+	return version.preRelease != ""
+}
+
 func CompareDesc(v1, v2 Version) bool {
 	return v1.major > v2.major ||
 		(v1.major == v2.major && v1.minor > v2.minor) ||
 		(v1.major == v2.major && v1.minor == v2.minor && compareEmptyStringDesc(v1.patchString, v2.patchString)) ||
 		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch > v2.patch) ||
 		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch == v2.patch && compareEmptyStringDesc(v1.buildString, v2.buildString)) ||
-		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch == v2.patch && v1.buildString == v2.buildString && v1.build > v2.build)
+		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch == v2.patch && v1.buildString == v2.buildString && v1.build > v2.build) ||
+		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch == v2.patch && v1.buildString == v2.buildString && v1.build == v2.build && comparePreRelease(v1, v2) > 0) ||
+		(v1.major == v2.major && v1.minor == v2.minor && v1.patchString == v2.patchString && v1.patch == v2.patch && v1.buildString == v2.buildString && v1.build == v2.build && comparePreRelease(v1, v2) == 0 && v1.distribution < v2.distribution)
+}
+
+// comparePreRelease puts GA release after all its pre-releases, alpha < beta < ea/eap < rc
+func comparePreRelease(v1, v2 Version) int {
+	if v1.preRelease == v2.preRelease {
+		return compareInts(v1.preReleaseNumber, v2.preReleaseNumber)
+	}
+	if v1.preRelease == "" {
+		return 1
+	}
+	if v2.preRelease == "" {
+		return -1
+	}
+	return compareInts(preReleaseRanks[v1.preRelease], preReleaseRanks[v2.preRelease])
+}
+
+func compareInts(i1, i2 int) int {
+	if i1 < i2 {
+		return -1
+	}
+	if i1 > i2 {
+		return 1
+	}
+	return 0
 }
 
 func compareEmptyStringDesc(v1, v2 string) bool {
@@ -0,0 +1,281 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var operators = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+var wildcardPattern = regexp.MustCompile(`(^|\.)[xX*](\.|$)`)
+
+// Constraint is a disjunction (||) of conjunctions (space separated) of terms like ^18.2, ~3.8.1, >=17, !=3.9.0 or 18.x,
+// term without operator matches versions starting with it, e.g. 18 matches 18.2.1
+type Constraint struct {
+	expression   string
+	alternatives [][]constraintTerm
+}
+
+type constraintTerm struct {
+	operator string
+	version  Version
+}
+
+// IsConstraint tells whether expression needs more than prefix matching
+func IsConstraint(expression string) bool {
+	return strings.ContainsAny(expression, "<>=!^~| ,") || wildcardPattern.MatchString(expression)
+}
+
+func ParseConstraint(expression string) (Constraint, error) {
+	alternativeExpressions := strings.Split(expression, "||")
+	alternatives := make([][]constraintTerm, 0, len(alternativeExpressions))
+	for _, alternativeExpression := range alternativeExpressions {
+		tokens := strings.Fields(strings.ReplaceAll(alternativeExpression, ",", " "))
+		if len(tokens) == 0 && len(alternativeExpressions) > 1 {
+			return Constraint{}, fmt.Errorf("%s is not a valid version constraint", expression)
+		}
+		terms := make([]constraintTerm, 0, len(tokens))
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+			// operator separated by space from its version, e.g. ">= 17"
+			if isOperator(token) && i+1 < len(tokens) {
+				i++
+				token += tokens[i]
+			}
+			term, err := parseConstraintTerm(token)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("%s is not a valid version constraint", expression)
+			}
+			terms = append(terms, term)
+		}
+		alternatives = append(alternatives, terms)
+	}
+	return Constraint{expression: expression, alternatives: alternatives}, nil
+}
+
+func isOperator(token string) bool {
+	for _, operator := range operators {
+		if token == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func parseConstraintTerm(token string) (constraintTerm, error) {
+	operator := ""
+	for _, candidate := range operators {
+		if strings.HasPrefix(token, candidate) {
+			operator = candidate
+			break
+		}
+	}
+	versionString := strings.TrimPrefix(token, operator)
+	if location := wildcardPattern.FindStringIndex(versionString); location != nil {
+		versionString = versionString[:location[0]]
+	}
+	if operator != "" && operator != "=" && operator != "!=" && strings.TrimPrefix(versionString, "v") == "" {
+		return constraintTerm{}, fmt.Errorf("%s is not a valid version constraint", token)
+	}
+	version, err := parseVersion(versionString)
+	if err != nil {
+		return constraintTerm{}, err
+	}
+	return constraintTerm{operator: operator, version: version}, nil
+}
+
+func (constraint Constraint) String() string {
+	return constraint.expression
+}
+
+func (constraint Constraint) Matches(version Version) bool {
+	for _, terms := range constraint.alternatives {
+		matches := true
+		for _, term := range terms {
+			if !term.matches(version) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (constraint Constraint) namesPreRelease() bool {
+	for _, terms := range constraint.alternatives {
+		for _, term := range terms {
+			if term.version.IsPreRelease() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Distribution is vendor the constraint refers to, empty for default one
+func (constraint Constraint) Distribution() string {
+	for _, terms := range constraint.alternatives {
+		for _, term := range terms {
+			if term.version.distribution != "" {
+				return term.version.distribution
+			}
+		}
+	}
+	return ""
+}
+
+// Qualify appends distribution to every term, e.g. >=17 <21.x with tem becomes >=17-tem <21-tem,
+// pre-release terms fail as distributions other than the default one are not listed with pre-releases
+func (constraint Constraint) Qualify(distribution string) (string, error) {
+	alternatives := make([]string, 0, len(constraint.alternatives))
+	for _, terms := range constraint.alternatives {
+		qualifiedTerms := make([]string, 0, len(terms))
+		for _, term := range terms {
+			if term.version.IsPreRelease() || term.version.distribution != "" {
+				return "", fmt.Errorf("%s cannot be qualified with distribution %s", constraint.expression, distribution)
+			}
+			qualifiedTerms = append(qualifiedTerms, term.operator+strings.TrimSuffix(term.version.Value, ".")+"-"+distribution)
+		}
+		alternatives = append(alternatives, strings.Join(qualifiedTerms, " "))
+	}
+	return strings.Join(alternatives, " || "), nil
+}
+
+// reference is the version the constraint is built around, used to suggest the closest candidates
+func (constraint Constraint) reference() (Version, bool) {
+	for _, terms := range constraint.alternatives {
+		for _, term := range terms {
+			if term.version.major != -1 {
+				return term.version, true
+			}
+		}
+	}
+	return Version{}, false
+}
+
+func (term constraintTerm) matches(version Version) bool {
+	bound := term.version
+	if version.distribution != bound.distribution {
+		return term.operator == "!="
+	}
+	switch term.operator {
+	case ">":
+		return compareToBound(version, bound) > 0
+	case ">=":
+		return compareToBound(version, bound) >= 0
+	case "<":
+		return compareToBound(version, bound) < 0
+	case "<=":
+		return compareToBound(version, bound) <= 0
+	case "!=":
+		return !hasPrefix(version, bound)
+	case "^":
+		return compareToBound(version, bound) >= 0 && caretCompatible(version, bound)
+	case "~":
+		return compareToBound(version, bound) >= 0 && tildeCompatible(version, bound)
+	default:
+		return hasPrefix(version, bound)
+	}
+}
+
+func hasPrefix(v, prefix Version) bool {
+	return (v.major == prefix.major || prefix.major == -1) &&
+		(v.minor == prefix.minor || prefix.minor == -1) &&
+		(strings.HasPrefix(v.patchString, prefix.patchString) || prefix.patchString == "") &&
+		(v.patch == prefix.patch || prefix.patch == -1) &&
+		(strings.HasPrefix(v.buildString, prefix.buildString) || prefix.buildString == "") &&
+		(v.build == prefix.build || prefix.build == -1) &&
+		(prefix.preRelease == "" || comparePreReleaseToBound(v, prefix) == 0)
+}
+
+// compareToBound compares only numeric components present in bound, so 17.0.2 equals bound 17,
+// pre-release is lower than its bound, e.g. 1.25.rc1 < 1.25
+func compareToBound(v, bound Version) int {
+	components := [][2]int{{v.major, bound.major}, {v.minor, bound.minor}, {v.patch, bound.patch}, {v.build, bound.build}}
+	for _, component := range components {
+		if component[1] == -1 {
+			break
+		}
+		if component[0] != component[1] {
+			if component[0] < component[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if sameNumbers(v, bound) {
+		return comparePreReleaseToBound(v, bound)
+	}
+	return 0
+}
+
+func sameNumbers(v1, v2 Version) bool {
+	components := [][2]int{{v1.major, v2.major}, {v1.minor, v2.minor}, {v1.patch, v2.patch}, {v1.build, v2.build}}
+	for _, component := range components {
+		if zeroIfMissing(component[0]) != zeroIfMissing(component[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func zeroIfMissing(component int) int {
+	if component == -1 {
+		return 0
+	}
+	return component
+}
+
+// comparePreReleaseToBound treats missing pre-release number of bound as any, e.g. 1.25.rc2 equals bound 1.25.rc
+func comparePreReleaseToBound(v, bound Version) int {
+	if v.preRelease == bound.preRelease && bound.preReleaseNumber == -1 {
+		return 0
+	}
+	return comparePreRelease(v, bound)
+}
+
+// caretCompatible keeps the left-most non-zero component, e.g. ^18.2 allows 18.x, ^0.2 allows 0.2.x
+func caretCompatible(v, bound Version) bool {
+	switch {
+	case bound.major > 0 || bound.minor == -1:
+		return v.major == bound.major
+	case bound.minor > 0 || bound.patch == -1:
+		return v.major == bound.major && v.minor == bound.minor
+	default:
+		return v.major == bound.major && v.minor == bound.minor && v.patch == bound.patch
+	}
+}
+
+// tildeCompatible allows patch changes if minor is given, e.g. ~3.8.1 allows 3.8.x, ~3 allows 3.x
+func tildeCompatible(v, bound Version) bool {
+	if bound.minor == -1 {
+		return v.major == bound.major
+	}
+	return v.major == bound.major && v.minor == bound.minor
+}
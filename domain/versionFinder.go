@@ -22,14 +22,15 @@
 package domain
 
 import (
-	"errors"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-func FindVersion(version string, versions []string) (Version, int, error) {
+// FindVersion picks the highest of versions matching the version constraint,
+// pre-releases are considered only if included or named in the constraint
+func FindVersion(version string, versions []string, includePreReleases bool) (Version, int, error) {
 	parsedVersions, err := parseVersions(versions)
 	if err != nil {
 		return Version{}, -1, err
@@ -37,33 +38,56 @@ func FindVersion(version string, versions []string) (Version, int, error) {
 	sort.Slice(parsedVersions, func(i, j int) bool {
 		return CompareDesc(parsedVersions[i], parsedVersions[j])
 	})
-	parsedVersion, err := parseVersion(version)
+	constraint, err := ParseConstraint(version)
 	if err != nil {
 		return Version{}, -1, err
 	}
 
-	matchingVersion := Version{}
+	includePreReleases = includePreReleases || constraint.namesPreRelease()
 	for _, v := range parsedVersions {
-		if (v.major == parsedVersion.major || parsedVersion.major == -1) &&
-			(v.minor == parsedVersion.minor || parsedVersion.minor == -1) &&
-			(strings.HasPrefix(v.patchString, parsedVersion.patchString) || parsedVersion.patchString == "") &&
-			(v.patch == parsedVersion.patch || parsedVersion.patch == -1) &&
-			(strings.HasPrefix(v.buildString, parsedVersion.buildString) || parsedVersion.buildString == "") &&
-			(v.build == parsedVersion.build || parsedVersion.build == -1) {
-			matchingVersion = v
-			break
+		if v.IsPreRelease() && !includePreReleases || !constraint.Matches(v) {
+			continue
 		}
-	}
-	for i, v := range versions {
-		if v == matchingVersion.Value {
-			return matchingVersion, i, nil
+		for i, value := range versions {
+			if value == v.Value {
+				return v, i, nil
+			}
 		}
-
 	}
-	return Version{}, -1, errors.New("No version found for: " + version)
+	return Version{}, -1, &VersionNotFoundError{Version: version, Candidates: closestCandidates(constraint, parsedVersions)}
 
 }
 
+const candidatesCount = 3
+
+// closestCandidates takes versions around the one the constraint refers to, versions are sorted descending
+func closestCandidates(constraint Constraint, versions []Version) []string {
+	start := 0
+	if reference, ok := constraint.reference(); ok {
+		start = len(versions)
+		for i, v := range versions {
+			if compareToBound(v, reference) <= 0 {
+				start = i
+				break
+			}
+		}
+		if start > 0 {
+			start--
+		}
+		if start > len(versions)-candidatesCount {
+			start = len(versions) - candidatesCount
+		}
+		if start < 0 {
+			start = 0
+		}
+	}
+	candidates := make([]string, 0, candidatesCount)
+	for i := start; i < len(versions) && len(candidates) < candidatesCount; i++ {
+		candidates = append(candidates, versions[i].Value)
+	}
+	return candidates
+}
+
 func parseVersions(versions []string) ([]Version, error) {
 	parsedVersions := make([]Version, 0)
 	for _, version := range versions {
@@ -76,6 +100,13 @@ func parseVersions(versions []string) ([]Version, error) {
 	return parsedVersions, nil
 }
 
+var preReleasePattern = regexp.MustCompile(`(?i)^(.*?)[.\-]?(alpha|beta|rc|eap|ea)[.\-]?(\d*)$`)
+
+// distributionPattern matches qualifier made of vendor and flavour, e.g. tem-jre in 21.0.2-tem-jre or jre in -jre
+var distributionPattern = regexp.MustCompile(`^(|.*[0-9])-([a-z][a-z0-9]*(?:-[a-z][a-z0-9]*)*)$`)
+
+var preReleaseQualifierPattern = regexp.MustCompile(`^(alpha|beta|rc|eap|ea)[0-9]*$`)
+
 func parseVersion(version string) (Version, error) {
 	var versionWithoutV string
 	if strings.HasPrefix(version, "v") {
@@ -84,10 +115,25 @@ func parseVersion(version string) (Version, error) {
 		versionWithoutV = version
 	}
 
+	distribution := ""
+	if match := distributionPattern.FindStringSubmatch(versionWithoutV); match != nil && !preReleaseQualifierPattern.MatchString(strings.Split(match[2], "-")[0]) {
+		versionWithoutV = match[1]
+		distribution = match[2]
+	}
+
+	preRelease, preReleaseNumber := "", -1
+	if match := preReleasePattern.FindStringSubmatch(versionWithoutV); match != nil {
+		versionWithoutV = match[1]
+		preRelease = strings.ToLower(match[2])
+		if match[3] != "" {
+			preReleaseNumber, _ = strconv.Atoi(match[3])
+		}
+	}
+
 	splitVersion := strings.Split(versionWithoutV, ".")
 	var major, minor, patch, build = -1, -1, -1, -1
 	var patchString, buildString = "", ""
-	var errVersion = Version{Value: version, major: major, minor: minor, patchString: patchString, patch: patch, buildString: buildString, build: build}
+	var errVersion = Version{Value: version, major: major, minor: minor, patchString: patchString, patch: patch, buildString: buildString, build: build, preReleaseNumber: -1}
 	var err error
 	if len(splitVersion) > 0 && splitVersion[0] != "" {
 		major, err = strconv.Atoi(splitVersion[0])
@@ -124,5 +170,6 @@ func parseVersion(version string) (Version, error) {
 		return errVersion, err
 	}
 
-	return Version{Value: version, major: major, minor: minor, patchString: patchString, patch: patch, buildString: buildString, build: build}, nil
+	return Version{Value: version, major: major, minor: minor, patchString: patchString, patch: patch, buildString: buildString, build: build,
+		preRelease: preRelease, preReleaseNumber: preReleaseNumber, distribution: distribution}, nil
 }
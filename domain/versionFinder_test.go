@@ -76,7 +76,7 @@ func TestFindVersionForNode(t *testing.T) {
 		"20.0.1":  "v20.0.1",
 	}
 	for version, expected := range terms {
-		actual, _, err := ver.FindVersion(version, versions)
+		actual, _, err := ver.FindVersion(version, versions, false)
 		if err != nil {
 			t.Errorf("Error: %v", err)
 		}
@@ -114,7 +114,7 @@ func TestFindVersionForGo(t *testing.T) {
 		"1.9.2.rc2": "1.9.2.rc2",
 	}
 	for version, expected := range terms {
-		actual, _, err := ver.FindVersion(version, versions)
+		actual, _, err := ver.FindVersion(version, versions, false)
 		if err != nil {
 			t.Errorf("Error: %v", err)
 		}
@@ -139,7 +139,7 @@ func TestFindVersionForJava(t *testing.T) {
 		"18.0.2.1": "18.0.2.1",
 	}
 	for version, expected := range terms {
-		actual, _, err := ver.FindVersion(version, versions)
+		actual, _, err := ver.FindVersion(version, versions, false)
 		if err != nil {
 			t.Errorf("Error: %v", err)
 		}
@@ -149,6 +149,46 @@ func TestFindVersionForJava(t *testing.T) {
 	}
 }
 
+func TestFindVersionForJavaDistributions(t *testing.T) {
+	versions := []string{
+		"21.0.2",
+		"21.0.2-tem",
+		"21.0.1-tem",
+		"17.0.9-corretto",
+		"17.0.10",
+		"21.0.2-tem-jre",
+		"21.0.2-jre",
+	}
+	terms := map[string]string{
+		"":                 "21.0.2",
+		"21":               "21.0.2",
+		"17":               "17.0.10",
+		"21-tem":           "21.0.2-tem",
+		"21.0.1-tem":       "21.0.1-tem",
+		"17-corretto":      "17.0.9-corretto",
+		">=17-tem <22-tem": "21.0.2-tem",
+		"^21-tem":          "21.0.2-tem",
+		"17.0.9-corretto":  "17.0.9-corretto",
+		"21-tem-jre":       "21.0.2-tem-jre",
+		"-jre":             "21.0.2-jre",
+		"-tem-jre":         "21.0.2-tem-jre",
+	}
+	for version, expected := range terms {
+		actual, _, err := ver.FindVersion(version, versions, false)
+		if err != nil {
+			t.Errorf("Error for %v: %v", version, err)
+			continue
+		}
+		if actual.Value != expected {
+			t.Errorf("%v - expected: %v, but got: %v", version, expected, actual.Value)
+		}
+	}
+	_, _, err := ver.FindVersion("17-tem", versions, false)
+	if err == nil {
+		t.Errorf("Expected no version of other distribution")
+	}
+}
+
 func TestFindVersionForSVM(t *testing.T) {
 	versions := []string{
 		"v0.1.0",
@@ -165,7 +205,7 @@ func TestFindVersionForSVM(t *testing.T) {
 		"v0.4": "v0.4.0",
 	}
 	for version, expected := range terms {
-		actual, _, err := ver.FindVersion(version, versions)
+		actual, _, err := ver.FindVersion(version, versions, false)
 		if err != nil {
 			t.Errorf("Error: %v", err)
 		}
@@ -189,11 +229,11 @@ func TestFindNoVersion(t *testing.T) {
 		"v20.0.10",
 	}
 	for _, version := range terms {
-		actual, _, err := ver.FindVersion(version, versions)
+		actual, _, err := ver.FindVersion(version, versions, false)
 		if err == nil {
 			t.Errorf("Expected error, but got response: %v", actual)
 		}
-		expectedError := fmt.Sprintf("No version found for: %v", version)
+		expectedError := fmt.Sprintf("No version found for: %v (closest candidates: v20.0.9)", version)
 		if err.Error() != expectedError {
 			t.Errorf("Expected: %v,. but got: %v", expectedError, err.Error())
 		}
@@ -212,7 +252,7 @@ func TestFindIndex(t *testing.T) {
 		"v20.2",
 	}
 	for index, version := range terms {
-		_, actualIndex, err := ver.FindVersion(version, versions)
+		_, actualIndex, err := ver.FindVersion(version, versions, false)
 		if err != nil {
 			t.Errorf("Error: %v", err)
 		}
@@ -221,3 +261,110 @@ func TestFindIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestFindVersionWithConstraint(t *testing.T) {
+	versions := []string{
+		"16.20.2",
+		"17.0.1",
+		"18.1.0",
+		"18.2.0",
+		"18.2.5",
+		"18.19.1",
+		"19.0.0",
+		"20.11.0",
+		"21.6.1",
+		"3.8.1",
+		"3.8.4",
+		"3.9.0",
+		"3.9.6",
+	}
+	terms := map[string]string{
+		"^18.2":          "18.19.1",
+		"^18.2.5":        "18.19.1",
+		"~18.2":          "18.2.5",
+		"~3.8.1":         "3.8.4",
+		">=17 <21":       "20.11.0",
+		">= 17, < 20":    "19.0.0",
+		"18.x || 20.x":   "20.11.0",
+		"18.x":           "18.19.1",
+		"3.*":            "3.9.6",
+		"!=3.9.6 <4":     "3.9.0",
+		">3.8.1 <3.9":    "3.8.4",
+		"<=18":           "18.19.1",
+		"<17 || ^3.8":    "16.20.2",
+		"=18.2.0":        "18.2.0",
+		"*":              "21.6.1",
+		">21.6.1 || 3.8": "3.8.4",
+	}
+	for version, expected := range terms {
+		actual, _, err := ver.FindVersion(version, versions, false)
+		if err != nil {
+			t.Errorf("Error for %v: %v", version, err)
+			continue
+		}
+		if actual.Value != expected {
+			t.Errorf("For %v expected: %v, but got: %v", version, expected, actual.Value)
+		}
+	}
+}
+
+func TestFindVersionCandidates(t *testing.T) {
+	versions := []string{"16.20.2", "18.2.0", "18.19.1", "20.11.0", "21.6.1"}
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "^19", want: "No version found for: ^19 (closest candidates: 20.11.0, 18.19.1, 18.2.0)"},
+		{version: ">22", want: "No version found for: >22 (closest candidates: 21.6.1, 20.11.0, 18.19.1)"},
+		{version: "<16", want: "No version found for: <16 (closest candidates: 18.19.1, 18.2.0, 16.20.2)"},
+		{version: "~18.3", want: "No version found for: ~18.3 (closest candidates: 18.19.1, 18.2.0, 16.20.2)"},
+	}
+	for _, tt := range tests {
+		_, _, err := ver.FindVersion(tt.version, versions, false)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Expected: %v, but got: %v", tt.want, err)
+		}
+	}
+}
+
+func TestFindVersionInvalidConstraint(t *testing.T) {
+	for _, version := range []string{">=", "18 ||", "^a.b", "~"} {
+		_, _, err := ver.FindVersion(version, []string{"18.0.0"}, false)
+		if err == nil {
+			t.Errorf("Expected error for %v", version)
+		}
+	}
+}
+
+func TestFindVersionWithPreReleases(t *testing.T) {
+	versions := []string{"1.22rc1", "1.22rc2", "1.21.6", "1.22beta1", "21-ea"}
+	tests := []struct {
+		version            string
+		includePreReleases bool
+		expected           string
+	}{
+		{version: "", includePreReleases: false, expected: "1.21.6"},
+		{version: "1", includePreReleases: false, expected: "1.21.6"},
+		{version: "", includePreReleases: true, expected: "21-ea"},
+		{version: "1.22", includePreReleases: true, expected: "1.22rc2"},
+		{version: "1.22rc", includePreReleases: false, expected: "1.22rc2"},
+		{version: "1.22rc1", includePreReleases: false, expected: "1.22rc1"},
+		{version: "1.22.beta", includePreReleases: false, expected: "1.22beta1"},
+		{version: "<1.22", includePreReleases: true, expected: "1.22rc2"},
+		{version: "21-ea", includePreReleases: false, expected: "21-ea"},
+	}
+	for _, tt := range tests {
+		actual, _, err := ver.FindVersion(tt.version, versions, tt.includePreReleases)
+		if err != nil {
+			t.Errorf("Error for %v: %v", tt.version, err)
+			continue
+		}
+		if actual.Value != tt.expected {
+			t.Errorf("For %v expected: %v, but got: %v", tt.version, tt.expected, actual.Value)
+		}
+	}
+	_, _, err := ver.FindVersion("1.22", versions, false)
+	if err == nil {
+		t.Errorf("Expected error as 1.22 has only pre-releases")
+	}
+}
@@ -27,7 +27,11 @@ import (
 )
 
 func ValidateVersion(version string) error {
-	match, err := regexp.MatchString("^(v)?(0|[1-9][0-9]*)(\\.(0|[1-9][0-9]*)?){0,2}$", version)
+	if IsConstraint(version) {
+		_, err := ParseConstraint(version)
+		return err
+	}
+	match, err := regexp.MatchString("^((v)?(0|[1-9][0-9]*)(\\.(0|[1-9][0-9]*)?){0,2}([.-]?(?i:alpha|beta|rc|eap|ea)[.-]?[0-9]*)?(-[a-z][a-z0-9]*)*|(-[a-z][a-z0-9]*)+)$", version)
 	if err != nil {
 		return err
 	}
@@ -30,6 +30,9 @@ import (
 func TestCorrectVersions(t *testing.T) {
 	versions := []string{"v10.11.12", "10.11.12", "v10.11", "10.11", "v10", "10",
 		"v10.11.", "10.11.", "v10.", "10.", "1.0", "1.1", "0.1", "0.2",
+		"^18.2", "~3.8.1", ">=17 <21", "18.x || 20.x", "!=3.9.0",
+		"1.22rc1", "1.22.rc1", "1.25.beta", "21-ea", "2024.1-EAP", "1.0.0-alpha.1",
+		"21-tem", "17.0.9-corretto", ">=17-graalce",
 	}
 	for _, v := range versions {
 		err := ver.ValidateVersion(v)
@@ -40,7 +43,7 @@ func TestCorrectVersions(t *testing.T) {
 }
 
 func TestIncorrectVersions(t *testing.T) {
-	versions := []string{"a.b.c", "1.2.a", "x1.2.3"}
+	versions := []string{"a.b.c", "1.2.a", "x1.2.3", "1.2.gamma1"}
 	for _, v := range versions {
 		err := ver.ValidateVersion(v)
 		expectedErr := fmt.Sprintf("%s is not a valid version", v)
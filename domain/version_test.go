@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package domain_test
+
+import (
+	ver "github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCompareDescWithPreReleases(t *testing.T) {
+	values := []string{"1.21.6", "1.22.rc1", "1.22", "1.22beta1", "1.22rc2", "1.22.1", "1.22alpha", "21-ea", "21", "21.0.1"}
+	versions := make([]ver.Version, len(values))
+	for i, value := range values {
+		versions[i] = ver.Ver(value, t)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return ver.CompareDesc(versions[i], versions[j])
+	})
+	actual := make([]string, len(versions))
+	for i, version := range versions {
+		actual[i] = version.Value
+	}
+	expected := []string{"21.0.1", "21", "21-ea", "1.22.1", "1.22", "1.22rc2", "1.22.rc1", "1.22beta1", "1.22alpha", "1.21.6"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected: %v, but got: %v", expected, actual)
+	}
+}
+
+func TestIsPreRelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.22":            false,
+		"v20.3.1":         false,
+		"1.22rc1":         true,
+		"1.25.beta1":      true,
+		"21-ea":           true,
+		"2024.1-EAP":      true,
+		"2024.2.eap10180": true,
+		"2024.2.rc12345":  true,
+		"1.0.0-alpha.1":   true,
+	}
+	for value, expected := range tests {
+		if actual := ver.Ver(value, t).IsPreRelease(); actual != expected {
+			t.Errorf("IsPreRelease(%v) = %v, expected: %v", value, actual, expected)
+		}
+	}
+}
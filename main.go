@@ -23,6 +23,8 @@ package main
 
 import (
 	"github.com/pkk82/soft-ver-man/cmd"
+	"github.com/pkk82/soft-ver-man/cmd/declarative"
+	"github.com/pkk82/soft-ver-man/cmd/external"
 	_ "github.com/pkk82/soft-ver-man/cmd/golang"
 	_ "github.com/pkk82/soft-ver-man/cmd/intellij"
 	_ "github.com/pkk82/soft-ver-man/cmd/java"
@@ -33,5 +35,7 @@ import (
 )
 
 func main() {
+	declarative.Register()
+	external.Register()
 	cmd.Execute()
 }
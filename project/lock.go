@@ -0,0 +1,189 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const LockFileName = "svm.lock"
+
+const lockHeader = "# generated by soft-ver-man (svm sync), do not edit"
+
+// LockedArtifact - exact artifact installed for a tool of the project on the platform,
+// wanted version and options are copied from the manifest to detect its changes
+type LockedArtifact struct {
+	Name     string            `yaml:"name"`
+	Wanted   string            `yaml:"wanted"`
+	Options  map[string]string `yaml:"options,omitempty"`
+	Os       string            `yaml:"os"`
+	Arch     string            `yaml:"arch"`
+	Version  string            `yaml:"version"`
+	Url      string            `yaml:"url"`
+	FileName string            `yaml:"file"`
+	Type     string            `yaml:"type"`
+	Sha256   string            `yaml:"sha256"`
+	// Features of the package like jre or musl, musl is not qualified in version
+	Features []string `yaml:"features,omitempty"`
+}
+
+type Lock struct {
+	Artifacts []LockedArtifact `yaml:"artifacts"`
+}
+
+func (lock *Lock) Find(name, os, arch string) (LockedArtifact, bool) {
+	for _, artifact := range lock.Artifacts {
+		if artifact.Name == name && artifact.Os == os && artifact.Arch == arch {
+			return artifact, true
+		}
+	}
+	return LockedArtifact{}, false
+}
+
+// Verify fails if tools of the manifest are not locked for the platform as they are wanted,
+// or if the lock contains tools removed from the manifest
+func (lock *Lock) Verify(tools []Tool, os, arch string) error {
+	differences := make([]string, 0)
+	for _, tool := range tools {
+		artifact, found := lock.Find(tool.Name, os, arch)
+		if !found {
+			differences = append(differences, fmt.Sprintf("%v is not locked for %v/%v", tool.Name, os, arch))
+		} else if !artifact.locks(tool) {
+			differences = append(differences, fmt.Sprintf("%v is locked as %v, but %v is wanted", tool.Name, describe(artifact.Wanted, artifact.Options), describe(tool.Version, tool.Options)))
+		}
+	}
+	removed := make(map[string]bool)
+	for _, artifact := range lock.Artifacts {
+		if findTool(tools, artifact.Name) == nil && !removed[artifact.Name] {
+			removed[artifact.Name] = true
+			differences = append(differences, fmt.Sprintf("%v is locked, but not wanted", artifact.Name))
+		}
+	}
+	if len(differences) > 0 {
+		return errors.New(LockFileName + " does not match the manifest, run sync without --frozen: " + strings.Join(differences, ", "))
+	}
+	return nil
+}
+
+// Update replaces artifacts of the platform, artifacts of other platforms are kept if they still lock tools of the manifest
+func (lock *Lock) Update(tools []Tool, os, arch string, artifacts []LockedArtifact) Lock {
+	updated := Lock{Artifacts: make([]LockedArtifact, 0)}
+	for _, artifact := range lock.Artifacts {
+		if artifact.Os == os && artifact.Arch == arch {
+			continue
+		}
+		if tool := findTool(tools, artifact.Name); tool != nil && artifact.locks(*tool) {
+			updated.Artifacts = append(updated.Artifacts, artifact)
+		}
+	}
+	updated.Artifacts = append(updated.Artifacts, artifacts...)
+	toolIndex := func(artifact LockedArtifact) int {
+		for i, tool := range tools {
+			if tool.Name == artifact.Name {
+				return i
+			}
+		}
+		return len(tools)
+	}
+	sort.SliceStable(updated.Artifacts, func(i, j int) bool {
+		a, b := updated.Artifacts[i], updated.Artifacts[j]
+		if toolIndex(a) != toolIndex(b) {
+			return toolIndex(a) < toolIndex(b)
+		}
+		if a.Os != b.Os {
+			return a.Os < b.Os
+		}
+		return a.Arch < b.Arch
+	})
+	return updated
+}
+
+func (artifact LockedArtifact) locks(tool Tool) bool {
+	if artifact.Name != tool.Name || artifact.Wanted != tool.Version || len(artifact.Options) != len(tool.Options) {
+		return false
+	}
+	for key, value := range tool.Options {
+		if artifact.Options[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func findTool(tools []Tool, name string) *Tool {
+	for i := range tools {
+		if tools[i].Name == name {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+func describe(version string, options map[string]string) string {
+	if len(options) == 0 {
+		return version
+	}
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + ": " + options[key]
+	}
+	return version + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// ReadLock reads svm.lock from given directory, returns false if there is no lock file
+func ReadLock(dir string) (Lock, bool, error) {
+	lockPath := filepath.Join(dir, LockFileName)
+	exists, err := file.FileExists(lockPath)
+	if err != nil || !exists {
+		return Lock{}, false, err
+	}
+	content, err := file.ReadFile(lockPath)
+	if err != nil {
+		return Lock{}, false, err
+	}
+	var lock Lock
+	err = yaml.Unmarshal([]byte(content), &lock)
+	if err != nil {
+		return Lock{}, false, errors.New(lockPath + ": " + err.Error())
+	}
+	return lock, true, nil
+}
+
+func WriteLock(dir string, lock Lock) error {
+	content, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return file.OverrideFileWithContent(filepath.Join(dir, LockFileName), []string{lockHeader, strings.TrimSuffix(string(content), "\n"), ""})
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"github.com/pkk82/soft-ver-man/util/test"
+	"reflect"
+	"testing"
+)
+
+func Test_WriteAndReadLock(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	lock := Lock{Artifacts: []LockedArtifact{
+		{
+			Name:     "node",
+			Wanted:   "20",
+			Os:       "linux",
+			Arch:     "amd64",
+			Version:  "v20.1.3",
+			Url:      "https://nodejs.org/dist/v20.1.3/node-v20.1.3-linux-x64.tar.gz",
+			FileName: "node-v20.1.3-linux-x64.tar.gz",
+			Type:     "tar.gz",
+			Sha256:   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			Name:     "java",
+			Wanted:   "21",
+			Options:  map[string]string{"vendor": "tem", "package": "jre"},
+			Os:       "linux",
+			Arch:     "amd64",
+			Version:  "21.0.2-tem-jre",
+			Url:      "https://github.com/adoptium/temurin21-binaries/releases/download/jdk-21.0.2%2B13/OpenJDK21U-jre_x64_alpine-linux_hotspot_21.0.2_13.tar.gz",
+			FileName: "OpenJDK21U-jre_x64_alpine-linux_hotspot_21.0.2_13.tar.gz",
+			Type:     "tar.gz",
+			Sha256:   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			Features: []string{"jre", "musl"},
+		},
+	}}
+
+	err := WriteLock(dir, lock)
+	if err != nil {
+		t.Fatalf("WriteLock() error = %v", err)
+	}
+	got, found, err := ReadLock(dir)
+	if err != nil || !found {
+		t.Fatalf("ReadLock() error = %v, found = %v", err, found)
+	}
+	if !reflect.DeepEqual(got, lock) {
+		t.Errorf("ReadLock() = %v, want %v", got, lock)
+	}
+	if _, found := got.Find("maven", "linux", "amd64"); found {
+		t.Errorf("Find() found not locked artifact")
+	}
+	if _, found := got.Find("node", "darwin", "arm64"); found {
+		t.Errorf("Find() found artifact locked for other platform")
+	}
+}
+
+var javaOptions = map[string]string{"vendor": "tem"}
+
+var platformLock = Lock{Artifacts: []LockedArtifact{
+	{Name: "node", Wanted: "20", Os: "darwin", Arch: "arm64", Version: "v20.1.3"},
+	{Name: "node", Wanted: "20", Os: "linux", Arch: "amd64", Version: "v20.1.3"},
+	{Name: "java", Wanted: "21", Options: javaOptions, Os: "linux", Arch: "amd64", Version: "21.0.2-tem"},
+}}
+
+func TestLock_Verify(t *testing.T) {
+	tests := []struct {
+		name          string
+		tools         []Tool
+		os            string
+		arch          string
+		expectedError string
+	}{
+		{
+			name:  "matching",
+			tools: []Tool{{Name: "node", Version: "20"}, {Name: "java", Version: "21", Options: map[string]string{"vendor": "tem"}}},
+			os:    "linux", arch: "amd64",
+		},
+		{
+			name:  "changed version",
+			tools: []Tool{{Name: "node", Version: "22"}, {Name: "java", Version: "21", Options: javaOptions}},
+			os:    "linux", arch: "amd64",
+			expectedError: "svm.lock does not match the manifest, run sync without --frozen: node is locked as 20, but 22 is wanted",
+		},
+		{
+			name:  "changed options",
+			tools: []Tool{{Name: "node", Version: "20"}, {Name: "java", Version: "21", Options: map[string]string{"vendor": "zulu"}}},
+			os:    "linux", arch: "amd64",
+			expectedError: "svm.lock does not match the manifest, run sync without --frozen: java is locked as 21 (vendor: tem), but 21 (vendor: zulu) is wanted",
+		},
+		{
+			name:  "added and removed tools",
+			tools: []Tool{{Name: "node", Version: "20"}, {Name: "maven", Version: "3"}},
+			os:    "linux", arch: "amd64",
+			expectedError: "svm.lock does not match the manifest, run sync without --frozen: maven is not locked for linux/amd64, java is locked, but not wanted",
+		},
+		{
+			name:  "other platform",
+			tools: []Tool{{Name: "node", Version: "20"}, {Name: "java", Version: "21", Options: javaOptions}},
+			os:    "darwin", arch: "arm64",
+			expectedError: "svm.lock does not match the manifest, run sync without --frozen: java is not locked for darwin/arm64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := platformLock.Verify(tt.tools, tt.os, tt.arch)
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("Verify() unexpected error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestLock_Update(t *testing.T) {
+	tools := []Tool{{Name: "java", Version: "21", Options: javaOptions}, {Name: "node", Version: "22"}}
+	artifacts := []LockedArtifact{
+		{Name: "java", Wanted: "21", Options: javaOptions, Os: "linux", Arch: "amd64", Version: "21.0.3-tem"},
+		{Name: "node", Wanted: "22", Os: "linux", Arch: "amd64", Version: "v22.2.0"},
+	}
+	got := platformLock.Update(tools, "linux", "amd64", artifacts)
+	// darwin node locks 20, so it is dropped
+	want := Lock{Artifacts: artifacts}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() = %v, want %v", got, want)
+	}
+
+	darwinArtifacts := []LockedArtifact{
+		{Name: "java", Wanted: "21", Options: javaOptions, Os: "darwin", Arch: "arm64", Version: "21.0.3-tem"},
+		{Name: "node", Wanted: "22", Os: "darwin", Arch: "arm64", Version: "v22.2.0"},
+	}
+	got = got.Update(tools, "darwin", "arm64", darwinArtifacts)
+	want = Lock{Artifacts: []LockedArtifact{darwinArtifacts[0], artifacts[0], darwinArtifacts[1], artifacts[1]}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Update() = %v, want %v", got, want)
+	}
+}
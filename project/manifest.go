@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+)
+
+const ManifestFileName = "svm.yaml"
+
+// Tool - software wanted by the project, e.g. node: 20,
+// or with options choosing distribution, e.g. java: {version: 21, vendor: tem, package: jre}
+type Tool struct {
+	Name    string
+	Version string
+	Options map[string]string
+}
+
+type Manifest struct {
+	Path  string
+	Tools []Tool
+}
+
+func (manifest *Manifest) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return errors.New("manifest must be a mapping of software to version")
+	}
+	tools := make([]Tool, 0)
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		nameNode, versionNode := value.Content[i], value.Content[i+1]
+		if versionNode.Kind == yaml.MappingNode {
+			tool, err := parseToolWithOptions(nameNode.Value, versionNode)
+			if err != nil {
+				return err
+			}
+			tools = append(tools, tool)
+			continue
+		}
+		if versionNode.Kind != yaml.ScalarNode {
+			return fmt.Errorf("version of %v must be a scalar (line %v)", nameNode.Value, versionNode.Line)
+		}
+		tools = append(tools, Tool{Name: nameNode.Value, Version: versionNode.Value})
+	}
+	manifest.Tools = tools
+	return nil
+}
+
+func parseToolWithOptions(name string, value *yaml.Node) (Tool, error) {
+	tool := Tool{Name: name, Options: make(map[string]string)}
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		keyNode, valueNode := value.Content[i], value.Content[i+1]
+		if valueNode.Kind != yaml.ScalarNode {
+			return Tool{}, fmt.Errorf("%v of %v must be a scalar (line %v)", keyNode.Value, name, valueNode.Line)
+		}
+		if keyNode.Value == "version" {
+			tool.Version = valueNode.Value
+		} else {
+			tool.Options[keyNode.Value] = valueNode.Value
+		}
+	}
+	return tool, nil
+}
+
+// ReadManifest reads svm.yaml from given directory, returns false if there is no manifest
+func ReadManifest(dir string) (Manifest, bool, error) {
+	manifestPath := filepath.Join(dir, ManifestFileName)
+	exists, err := file.FileExists(manifestPath)
+	if err != nil || !exists {
+		return Manifest{}, false, err
+	}
+	content, err := file.ReadFile(manifestPath)
+	if err != nil {
+		return Manifest{}, false, err
+	}
+	manifest, err := parseManifest(content)
+	if err != nil {
+		return Manifest{}, false, fmt.Errorf("%v: %w", manifestPath, err)
+	}
+	manifest.Path = manifestPath
+	return manifest, true, nil
+}
+
+func parseManifest(content string) (Manifest, error) {
+	var manifest Manifest
+	err := yaml.Unmarshal([]byte(content), &manifest)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
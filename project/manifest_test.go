@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Tool
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			content: "{}",
+			want:    []Tool{},
+		},
+		{
+			name:    "order and raw versions are kept",
+			content: "java: 17\nmvn: 3.10\nnode: v20.1\n",
+			want:    []Tool{{Name: "java", Version: "17"}, {Name: "mvn", Version: "3.10"}, {Name: "node", Version: "v20.1"}},
+		},
+		{
+			name:    "options",
+			content: "java:\n  version: 21\n  vendor: tem\n  package: jre\nnode: 20\n",
+			want: []Tool{
+				{Name: "java", Version: "21", Options: map[string]string{"vendor": "tem", "package": "jre"}},
+				{Name: "node", Version: "20"},
+			},
+		},
+		{
+			name:    "nested option",
+			content: "java:\n  version: 21\n  vendor: [tem]\n",
+			wantErr: true,
+		},
+		{
+			name:    "not a mapping",
+			content: "- java\n- node\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseManifest(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseManifest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Tools, tt.want) {
+				t.Errorf("parseManifest() = %v, want %v", got.Tools, tt.want)
+			}
+		})
+	}
+}
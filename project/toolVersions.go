@@ -0,0 +1,198 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const ToolVersionsFileName = ".tool-versions"
+
+type asdfPlugin struct {
+	name string
+	// directory inside asdf installation of a version, where software home is located
+	homeSubDir string
+}
+
+// asdf plugin names of software known under different name by soft-ver-man
+var asdfPlugins = map[string]asdfPlugin{
+	"node":   {name: "nodejs"},
+	"go":     {name: "golang", homeSubDir: "go"},
+	"mvn":    {name: "maven"},
+	"java":   {name: "java"},
+	"kotlin": {name: "kotlin", homeSubDir: "kotlinc"},
+}
+
+// FromAsdfName maps asdf plugin name to soft-ver-man plugin name
+func FromAsdfName(asdfName string) string {
+	for name, plugin := range asdfPlugins {
+		if plugin.name == asdfName {
+			return name
+		}
+	}
+	return asdfName
+}
+
+// ToAsdfName maps soft-ver-man plugin name to asdf plugin name
+func ToAsdfName(name string) string {
+	if plugin, ok := asdfPlugins[name]; ok {
+		return plugin.name
+	}
+	return name
+}
+
+// AsdfHomeSubDir returns directory inside asdf installation where software home is located
+func AsdfHomeSubDir(name string) string {
+	return asdfPlugins[name].homeSubDir
+}
+
+var asdfVendorPrefix = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z][a-z0-9]*)*-`)
+
+// asdf java vendors and flavours mapped to qualifiers of soft-ver-man, other parts like corretto are the same
+var asdfJavaQualifiers = map[string]string{"temurin": "tem", "graalvm-community": "graalce", "zulu": "", "javafx": "fx"}
+
+// FromAsdfVersion converts asdf version of the software to version understood by soft-ver-man,
+// e.g. java temurin-jre-17.0.9+9 to 17.0.9-tem-jre
+func FromAsdfVersion(name, version string) string {
+	prefix := asdfVendorPrefix.FindString(version)
+	withoutBuild, _, _ := strings.Cut(strings.TrimPrefix(version, prefix), "+")
+	if name != "java" || prefix == "" {
+		return withoutBuild
+	}
+	return withoutBuild + asdfJavaQualifier(strings.TrimSuffix(prefix, "-"))
+}
+
+// asdfJavaQualifier maps prefix like temurin-jre to qualifier like -tem-jre, zulu is the default vendor,
+// unknown vendors are kept to be reported as unsupported instead of installing the default one
+func asdfJavaQualifier(prefix string) string {
+	for asdfName, qualifier := range asdfJavaQualifiers {
+		if prefix == asdfName || strings.HasPrefix(prefix, asdfName+"-") {
+			prefix = qualifier + strings.TrimPrefix(prefix, asdfName)
+			break
+		}
+	}
+	parts := make([]string, 0)
+	for _, part := range strings.Split(prefix, "-") {
+		if qualifier, ok := asdfJavaQualifiers[part]; ok {
+			part = qualifier
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "-" + strings.Join(parts, "-")
+}
+
+// ToAsdfVersion converts version of soft-ver-man to version written in .tool-versions
+func ToAsdfVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+// ReadToolVersions reads asdf .tool-versions from given directory, returns false if there is no such file;
+// only the first (preferred) version of each tool is taken
+func ReadToolVersions(dir string) (Manifest, bool, error) {
+	toolVersionsPath := filepath.Join(dir, ToolVersionsFileName)
+	exists, err := file.FileExists(toolVersionsPath)
+	if err != nil || !exists {
+		return Manifest{}, false, err
+	}
+	content, err := file.ReadFile(toolVersionsPath)
+	if err != nil {
+		return Manifest{}, false, err
+	}
+	tools, err := parseToolVersions(content)
+	if err != nil {
+		return Manifest{}, false, fmt.Errorf("%v: %w", toolVersionsPath, err)
+	}
+	return Manifest{Path: toolVersionsPath, Tools: tools}, true, nil
+}
+
+func parseToolVersions(content string) ([]Tool, error) {
+	tools := make([]Tool, 0)
+	for i, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("no version for %v (line %v)", fields[0], i+1)
+		}
+		name := FromAsdfName(fields[0])
+		tools = append(tools, Tool{Name: name, Version: FromAsdfVersion(name, fields[1])})
+	}
+	return tools, nil
+}
+
+// WriteToolVersion sets version of software in .tool-versions of given directory, other lines are kept intact
+func WriteToolVersion(dir, name, version string) error {
+	toolVersionsPath := filepath.Join(dir, ToolVersionsFileName)
+	exists, err := file.FileExists(toolVersionsPath)
+	if err != nil {
+		return err
+	}
+	content := ""
+	if exists {
+		content, err = file.ReadFile(toolVersionsPath)
+		if err != nil {
+			return err
+		}
+	}
+	return file.OverrideFileWithContent(toolVersionsPath, []string{updateToolVersions(content, ToAsdfName(name), ToAsdfVersion(version))})
+}
+
+func updateToolVersions(content, asdfName, asdfVersion string) string {
+	newLine := asdfName + " " + asdfVersion
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = []string{}
+	}
+	updated := false
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == asdfName {
+			lines[i] = newLine
+			updated = true
+		}
+	}
+	if !updated {
+		lines = append(lines, newLine)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// FindManifest reads svm.yaml from given directory and falls back to asdf .tool-versions
+func FindManifest(dir string) (Manifest, bool, error) {
+	manifest, found, err := ReadManifest(dir)
+	if err != nil || found {
+		return manifest, found, err
+	}
+	return ReadToolVersions(dir)
+}
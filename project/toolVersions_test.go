@@ -0,0 +1,127 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseToolVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Tool
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    []Tool{},
+		},
+		{
+			name:    "asdf names and versions are mapped",
+			content: "# toolchain\nnodejs 20.1.3 18.2.0\ngolang 1.22.1 # comment\n\njava temurin-17.0.9+9\nmaven 3.9.6\n",
+			want: []Tool{
+				{Name: "node", Version: "20.1.3"},
+				{Name: "go", Version: "1.22.1"},
+				{Name: "java", Version: "17.0.9-tem"},
+				{Name: "mvn", Version: "3.9.6"},
+			},
+		},
+		{
+			name:    "no version",
+			content: "nodejs\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseToolVersions(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseToolVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseToolVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromAsdfVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "java", version: "temurin-21.0.2+13.0.LTS", want: "21.0.2-tem"},
+		{name: "java", version: "temurin-jre-21.0.2+13.0.LTS", want: "21.0.2-tem-jre"},
+		{name: "java", version: "corretto-21.0.2.13.1", want: "21.0.2.13.1-corretto"},
+		{name: "java", version: "liberica-javafx-21.0.2+14", want: "21.0.2-liberica-fx"},
+		{name: "java", version: "graalvm-community-21.0.2", want: "21.0.2-graalce"},
+		{name: "java", version: "zulu-21.0.2", want: "21.0.2"},
+		{name: "java", version: "zulu-jre-21.0.2", want: "21.0.2-jre"},
+		{name: "java", version: "oracle-21.0.2", want: "21.0.2-oracle"},
+		{name: "java", version: "21.0.2-tem", want: "21.0.2-tem"},
+		{name: "node", version: "20.1.3", want: "20.1.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.version, func(t *testing.T) {
+			if got := FromAsdfVersion(tt.name, tt.version); got != tt.want {
+				t.Errorf("FromAsdfVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_updateToolVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    "nodejs 20.1.3\n",
+		},
+		{
+			name:    "replace existing",
+			content: "# toolchain\nnodejs 18.2.0\ngolang 1.22.1\n",
+			want:    "# toolchain\nnodejs 20.1.3\ngolang 1.22.1\n",
+		},
+		{
+			name:    "append",
+			content: "golang 1.22.1",
+			want:    "golang 1.22.1\nnodejs 20.1.3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateToolVersions(tt.content, ToAsdfName("node"), ToAsdfVersion("v20.1.3")); got != tt.want {
+				t.Errorf("updateToolVersions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"path/filepath"
+	"strings"
+)
+
+// ReadVersionFile reads version from the first meaningful line of a file like .nvmrc or .java-version
+func ReadVersionFile(dir, fileName string) (domain.ProjectVersion, bool, error) {
+	filePath := filepath.Join(dir, fileName)
+	exists, err := file.FileExists(filePath)
+	if err != nil || !exists {
+		return domain.ProjectVersion{}, false, err
+	}
+	content, err := file.ReadFile(filePath)
+	if err != nil {
+		return domain.ProjectVersion{}, false, err
+	}
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line != "" {
+			return domain.ProjectVersion{Version: line, File: filePath}, true, nil
+		}
+	}
+	return domain.ProjectVersion{}, false, nil
+}
+
+// ReadProperties reads key=value lines of a file like .sdkmanrc or maven-wrapper.properties
+func ReadProperties(filePath string) (map[string]string, bool, error) {
+	exists, err := file.FileExists(filePath)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	content, err := file.ReadFile(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	return parseProperties(content), true, nil
+}
+
+func parseProperties(content string) map[string]string {
+	properties := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if found {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
+const SdkmanrcFileName = ".sdkmanrc"
+
+// sdkmanJavaQualifiers maps sdkman java vendor ids to qualifiers of java plugin, zulu is the default vendor
+var sdkmanJavaQualifiers = map[string]string{"tem": "tem", "graalce": "graalce", "amzn": "corretto", "librca": "liberica", "zulu": ""}
+
+// sdkmanJavaFeatures maps suffixes of sdkman java versions to qualifiers of java plugin, e.g. 21.0.2.fx-zulu
+var sdkmanJavaFeatures = []struct{ suffix, qualifier string }{{".fx", "fx"}, {".crac", "crac"}}
+
+// ReadSdkmanrc reads version of sdkman candidate (e.g. java, maven) from .sdkmanrc,
+// sdkman java vendor is converted to qualifier, e.g. 17.0.9-amzn gives 17.0.9-corretto
+func ReadSdkmanrc(dir, candidate string) (domain.ProjectVersion, bool, error) {
+	filePath := filepath.Join(dir, SdkmanrcFileName)
+	properties, exists, err := ReadProperties(filePath)
+	if err != nil || !exists {
+		return domain.ProjectVersion{}, false, err
+	}
+	version, found := properties[candidate]
+	if !found || version == "" {
+		return domain.ProjectVersion{}, false, nil
+	}
+	version, err = FromSdkmanVersion(candidate, version)
+	if err != nil {
+		return domain.ProjectVersion{}, false, fmt.Errorf("%v: %w", filePath, err)
+	}
+	return domain.ProjectVersion{Version: version, File: filePath}, true, nil
+}
+
+// FromSdkmanVersion converts sdkman version of the candidate to version understood by soft-ver-man,
+// e.g. java 21.0.2.fx-librca to 21.0.2-liberica-fx, unknown java vendors are rejected instead of installing the default one
+func FromSdkmanVersion(candidate, version string) (string, error) {
+	separator := strings.LastIndex(version, "-")
+	if separator < 0 {
+		return version, nil
+	}
+	if candidate != "java" {
+		return version[:separator], nil
+	}
+	version, vendor := version[:separator], version[separator+1:]
+	qualifier, ok := sdkmanJavaQualifiers[vendor]
+	if !ok {
+		return "", fmt.Errorf("sdkman java vendor %v is not supported", vendor)
+	}
+	parts := make([]string, 0)
+	if qualifier != "" {
+		parts = append(parts, qualifier)
+	}
+	for _, feature := range sdkmanJavaFeatures {
+		if strings.HasSuffix(version, feature.suffix) {
+			version = strings.TrimSuffix(version, feature.suffix)
+			parts = append(parts, feature.qualifier)
+		}
+	}
+	if len(parts) == 0 {
+		return version, nil
+	}
+	return version + "-" + strings.Join(parts, "-"), nil
+}
+
+// DetectedVersion - version wanted by the project found for a plugin
+type DetectedVersion struct {
+	Plugin         domain.Plugin
+	ProjectVersion domain.ProjectVersion
+}
+
+// Detect finds versions wanted by the project for every plugin able to detect them,
+// starting in dir and going up to the root directory; plugin failing to read its file is skipped with a warning
+func Detect(dir string) []DetectedVersion {
+	detectedVersions := make([]DetectedVersion, 0)
+	for _, plugin := range domain.GetPlugins() {
+		detector, ok := plugin.(domain.ProjectVersionDetector)
+		if !ok {
+			continue
+		}
+		projectVersion, found, err := detectUp(detector, dir)
+		if err != nil {
+			console.Warn(err)
+			continue
+		}
+		if found {
+			detectedVersions = append(detectedVersions, DetectedVersion{Plugin: plugin, ProjectVersion: projectVersion})
+		}
+	}
+	return detectedVersions
+}
+
+func detectUp(detector domain.ProjectVersionDetector, dir string) (domain.ProjectVersion, bool, error) {
+	currentDir := filepath.Clean(dir)
+	for {
+		projectVersion, found, err := detector.DetectProjectVersion(currentDir)
+		if err != nil || found {
+			return projectVersion, found, err
+		}
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			return domain.ProjectVersion{}, false, nil
+		}
+		currentDir = parentDir
+	}
+}
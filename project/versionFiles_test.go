@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package project
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_parseProperties(t *testing.T) {
+	content := "# Enable auto-env through the sdkman_auto_env config\njava=17.0.9-tem\n\nmaven = 3.9.6\n"
+	want := map[string]string{"java": "17.0.9-tem", "maven": "3.9.6"}
+	if got := parseProperties(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseProperties() = %v, want %v", got, want)
+	}
+}
+
+func Test_ReadSdkmanrc(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	test.CreateFile(dir, SdkmanrcFileName, []string{"java=17.0.9-tem", "maven=3.9.6"}, t)
+
+	got, found, err := ReadSdkmanrc(dir, "java")
+	want := domain.ProjectVersion{Version: "17.0.9-tem", File: filepath.Join(dir, SdkmanrcFileName)}
+	if err != nil || !found || got != want {
+		t.Errorf("ReadSdkmanrc() = %v, %v, %v, want %v", got, found, err, want)
+	}
+	_, found, err = ReadSdkmanrc(dir, "gradle")
+	if err != nil || found {
+		t.Errorf("ReadSdkmanrc() found = %v, err = %v for missing candidate", found, err)
+	}
+}
+
+func TestFromSdkmanVersion(t *testing.T) {
+	tests := []struct {
+		candidate string
+		version   string
+		want      string
+		wantErr   bool
+	}{
+		{candidate: "java", version: "21.0.2-tem", want: "21.0.2-tem"},
+		{candidate: "java", version: "21.0.2-graalce", want: "21.0.2-graalce"},
+		{candidate: "java", version: "21.0.2-amzn", want: "21.0.2-corretto"},
+		{candidate: "java", version: "21.0.2-librca", want: "21.0.2-liberica"},
+		{candidate: "java", version: "21.0.2-zulu", want: "21.0.2"},
+		{candidate: "java", version: "21.0.2.fx-zulu", want: "21.0.2-fx"},
+		{candidate: "java", version: "21.0.2.crac-librca", want: "21.0.2-liberica-crac"},
+		{candidate: "java", version: "21", want: "21"},
+		{candidate: "java", version: "22.1.0.1.r17-gln", wantErr: true},
+		{candidate: "java", version: "21.0.2-oracle", wantErr: true},
+		{candidate: "maven", version: "3.9.6", want: "3.9.6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.candidate+" "+tt.version, func(t *testing.T) {
+			got, err := FromSdkmanVersion(tt.candidate, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromSdkmanVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FromSdkmanVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type detectorFunc func(dir string) (domain.ProjectVersion, bool, error)
+
+func (f detectorFunc) DetectProjectVersion(dir string) (domain.ProjectVersion, bool, error) {
+	return f(dir)
+}
+
+func Test_detectUp(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	subDir := filepath.Join(dir, "module", "src")
+	test.CreateDir(subDir, t)
+	test.CreateFile(dir, ".nvmrc", []string{"", "v20.1.3", ""}, t)
+	detector := detectorFunc(func(dir string) (domain.ProjectVersion, bool, error) {
+		return ReadVersionFile(dir, ".nvmrc")
+	})
+
+	got, found, err := detectUp(detector, subDir)
+	want := domain.ProjectVersion{Version: "v20.1.3", File: filepath.Join(dir, ".nvmrc")}
+	if err != nil || !found || got != want {
+		t.Errorf("detectUp() = %v, %v, %v, want %v", got, found, err, want)
+	}
+}
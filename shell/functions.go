@@ -0,0 +1,70 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package shell
+
+import (
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"path"
+)
+
+// svm use <software> <version> - switches version only in the current shell session
+var useFunction = []string{
+	"svm() {",
+	"  if [[ \"$1\" == \"use\" ]]; then",
+	"    shift",
+	"    local svm_env",
+	"    svm_env=\"$(command svm env --clean-path \"$@\")\" || return $?",
+	"    eval \"$svm_env\"",
+	"  else",
+	"    command svm \"$@\"",
+	"  fi",
+	"}",
+}
+
+// switches versions to the ones wanted by the project on directory change
+var hookFunction = []string{
+	"_svm_hook() {",
+	"  [[ \"$PWD\" == \"$_SVM_HOOK_PWD\" ]] && return",
+	"  _SVM_HOOK_PWD=\"$PWD\"",
+	"  local svm_env",
+	"  svm_env=\"$(command svm hook-env 2>/dev/null)\" && eval \"$svm_env\"",
+	"}",
+	"if [[ -n \"$ZSH_VERSION\" ]]; then",
+	"  autoload -U add-zsh-hook",
+	"  add-zsh-hook chpwd _svm_hook",
+	"  _svm_hook",
+	"elif [[ -n \"$BASH_VERSION\" ]]; then",
+	"  [[ \"$PROMPT_COMMAND\" == *_svm_hook* ]] || PROMPT_COMMAND=\"_svm_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}\"",
+	"fi",
+}
+
+func initShellFunctions(homeDir string) error {
+	configDir := path.Join(homeDir, config.HomeConfigDir)
+	err := file.OverrideFileWithContent(path.Join(configDir, config.ShellFunctionsRcFile), append(append(useFunction, hookFunction...), ""))
+	if err != nil {
+		return err
+	}
+	initLine := bashToLoad(config.ShellFunctionsRcFile)
+	return file.AssertFileWithContent(path.Join(configDir, config.RcFile), initLine, []string{initLine})
+}
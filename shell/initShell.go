@@ -69,5 +69,5 @@ func initShell(finder domain.DirFinder) error {
 	if !atLeastOneExists {
 		return errors.New("at least of of the following files must exist: " + strings.Join(rcFiles[:], ", "))
 	}
-	return nil
+	return initShellFunctions(dir)
 }
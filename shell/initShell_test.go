@@ -43,9 +43,12 @@ func TestInitBash(t *testing.T) {
 			expectedError: "at least of of the following files must exist: .bashrc, .zshrc",
 		},
 		{
-			name:            "empty .bashrc",
-			files:           map[string][]string{bashrc: {}},
-			expectedContent: map[string][]string{bashrc: {"### soft-ver-man", `[[ -s "$HOME/.soft-ver-man/.svmmainrc" ]] && source "$HOME/.soft-ver-man/.svmmainrc"`, ""}},
+			name:  "empty .bashrc",
+			files: map[string][]string{bashrc: {}},
+			expectedContent: map[string][]string{
+				bashrc:                     {"### soft-ver-man", `[[ -s "$HOME/.soft-ver-man/.svmmainrc" ]] && source "$HOME/.soft-ver-man/.svmmainrc"`, ""},
+				".soft-ver-man/.svmmainrc": {`[[ -s "$HOME/.soft-ver-man/.svmshellrc" ]] && source "$HOME/.soft-ver-man/.svmshellrc"`, ""},
+			},
 		}, {
 			name:            "empty .zshrc",
 			files:           map[string][]string{zshrc: {}},
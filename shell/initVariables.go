@@ -31,7 +31,7 @@ import (
 
 func initVariables(finder domain.DirFinder, installedPackages domain.InstalledPackages) (domain.EnvVariables, error) {
 	plugin := installedPackages.Plugin
-	name := plugin.Name
+	name := plugin.Info().Name
 
 	homeDir, err := finder.HomeDir()
 	if err != nil {
@@ -76,18 +76,18 @@ func initSvmRcFile(name, homeDir string, finder domain.DirFinder) error {
 
 func initSpecificRcRile(installedPackages domain.InstalledPackages, homeDir string, plugin domain.Plugin) (domain.EnvVariables, error) {
 
-	variables, err := installedPackages.PrepareEnvVariables(plugin)
+	variables, err := installedPackages.PrepareEnvVariables(plugin, config.VersionGranularity(plugin.Info()))
 	if err != nil {
 		return domain.EnvVariables{}, err
 	}
 	extraVariables := domain.EnvVariables{}
-	if plugin.ExtraVariables != nil {
-		extraVariables = plugin.ExtraVariables(homeDir)
+	if extraEnv, ok := plugin.(domain.ExtraEnv); ok {
+		extraVariables = extraEnv.ExtraVariables(homeDir)
 	}
 	lines := variables.ToExport()
 	lines = append(lines, extraVariables.ToExport()...)
 
-	err = file.OverrideFileWithContent(path.Join(homeDir, config.HomeConfigDir, rcName(plugin.Name)), lines)
+	err = file.OverrideFileWithContent(path.Join(homeDir, config.HomeConfigDir, rcName(plugin.Info().Name)), lines)
 	if err != nil {
 		return domain.EnvVariables{}, err
 	}
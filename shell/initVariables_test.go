@@ -32,6 +32,15 @@ import (
 
 type expectedContentProvider func(dir string) []string
 
+type extraEnvPlugin struct {
+	domain.PluginInfo
+	extraVariables domain.EnvVariables
+}
+
+func (p extraEnvPlugin) ExtraVariables(homeDir string) domain.EnvVariables {
+	return p.extraVariables
+}
+
 func Test_initVariablesInSvmRc(t *testing.T) {
 	type args struct {
 		installedPackages domain.InstalledPackages
@@ -45,7 +54,7 @@ func Test_initVariablesInSvmRc(t *testing.T) {
 			name: "node installation",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{Name: "node", VersionGranularity: domain.VersionGranularityMajor, ExecutableRelativePath: "/bin"},
+					Plugin: domain.PluginInfo{Name: "node", VersionGranularity: domain.VersionGranularityMajor, ExecutableRelativePath: "/bin"},
 					Items: []domain.InstalledPackage{
 						{
 							Version:     domain.Ver("v20.1.3", t),
@@ -63,7 +72,7 @@ func Test_initVariablesInSvmRc(t *testing.T) {
 			name: "go installation",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{Name: "go", VersionGranularity: domain.VersionGranularityMajor, ExecutableRelativePath: "/bin"},
+					Plugin: domain.PluginInfo{Name: "go", VersionGranularity: domain.VersionGranularityMajor, ExecutableRelativePath: "/bin"},
 					Items: []domain.InstalledPackage{
 
 						{
@@ -105,7 +114,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "first installation as main",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "node",
 						EnvNamePrefix:          "NODE",
 						EnvNameSuffix:          "_HOME",
@@ -133,7 +142,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "first installation - no main",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "node",
 						EnvNamePrefix:          "NODE",
 						EnvNameSuffix:          "_HOME",
@@ -161,7 +170,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "another main installation",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "node",
 						EnvNamePrefix:          "NODE",
 						EnvNameSuffix:          "_HOME",
@@ -204,7 +213,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "another installation",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "node",
 						EnvNamePrefix:          "NODE",
 						EnvNameSuffix:          "_HOME",
@@ -247,7 +256,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "latest main installation - major granularity",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "node",
 						EnvNamePrefix:          "NODE",
 						EnvNameSuffix:          "_HOME",
@@ -281,7 +290,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "latest main installation - minor granularity",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "kotlin",
 						EnvNamePrefix:          "KOTLIN",
 						EnvNameSuffix:          "_HOME",
@@ -316,7 +325,7 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "soft-ver-man",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
+					Plugin: domain.PluginInfo{
 						Name:                   "soft-ver-man",
 						EnvNamePrefix:          "SVM",
 						EnvNameSuffix:          "_HOME",
@@ -345,21 +354,21 @@ func Test_initVariablesInSpecificRc(t *testing.T) {
 			name: "go",
 			args: args{
 				installedPackages: domain.InstalledPackages{
-					Plugin: domain.Plugin{
-						Name:          "go",
-						EnvNamePrefix: "GO",
-						EnvNameSuffix: "ROOT",
-						ExtraVariables: func(homedir string) domain.EnvVariables {
-							return domain.EnvVariables{
-								Variables: []domain.EnvVariable{
-									{
-										Name:        "GOPATH",
-										SuffixValue: "~/.go",
-									}},
-							}
+					Plugin: extraEnvPlugin{
+						PluginInfo: domain.PluginInfo{
+							Name:                   "go",
+							EnvNamePrefix:          "GO",
+							EnvNameSuffix:          "ROOT",
+							VersionGranularity:     domain.VersionGranularityMinor,
+							ExecutableRelativePath: "",
+						},
+						extraVariables: domain.EnvVariables{
+							Variables: []domain.EnvVariable{
+								{
+									Name:        "GOPATH",
+									SuffixValue: "~/.go",
+								}},
 						},
-						VersionGranularity:     domain.VersionGranularityMinor,
-						ExecutableRelativePath: "",
 					},
 					Items: []domain.InstalledPackage{
 						{
@@ -0,0 +1,118 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"os"
+	"regexp"
+	"sort"
+	"text/tabwriter"
+)
+
+var aliasPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+func SetAlias(plugin domain.Plugin, alias, version string) error {
+	if !aliasPattern.MatchString(alias) || domain.IsPluginChannel(plugin, alias) {
+		return fmt.Errorf("%v is not a valid alias, use letters, digits, - and _ and avoid channel names", alias)
+	}
+	if !domain.IsPluginChannel(plugin, version) {
+		err := domain.ValidateVersion(version)
+		if err != nil {
+			return err
+		}
+	}
+	aliases, err := config.LoadAliases(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	aliases[alias] = version
+	return config.StoreAliases(plugin.Info().Name, aliases)
+}
+
+func RemoveAlias(plugin domain.Plugin, alias string) error {
+	aliases, err := config.LoadAliases(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	if _, ok := aliases[alias]; !ok {
+		return errors.New("alias " + alias + " is not defined for " + plugin.Info().Name)
+	}
+	delete(aliases, alias)
+	return config.StoreAliases(plugin.Info().Name, aliases)
+}
+
+// IsAlias tells whether alias is defined for any software
+func IsAlias(alias string) bool {
+	for _, plugin := range domain.GetPlugins() {
+		aliases, err := config.LoadAliases(plugin.Info().Name)
+		if err != nil {
+			continue
+		}
+		if _, ok := aliases[alias]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func DisplayAliases() error {
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', tabwriter.Debug)
+	_, err := fmt.Fprintln(w, "Alias\t Software\t Version")
+	if err != nil {
+		return err
+	}
+	for _, plugin := range domain.GetPlugins() {
+		aliases, err := config.LoadAliases(plugin.Info().Name)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(aliases))
+		for alias := range aliases {
+			names = append(names, alias)
+		}
+		sort.Strings(names)
+		for _, alias := range names {
+			_, err = fmt.Fprintf(w, "%s\t %s\t %s\n", alias, plugin.Info().Name, aliases[alias])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// resolveAlias replaces user defined alias with its version, other selectors are returned unchanged
+func resolveAlias(plugin domain.Plugin, selector string) string {
+	aliases, err := config.LoadAliases(plugin.Info().Name)
+	if err != nil {
+		return selector
+	}
+	if version, ok := aliases[selector]; ok {
+		return version
+	}
+	return selector
+}
@@ -23,17 +23,57 @@
 package software
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
 )
 
-func Available(plugin domain.Plugin) {
-	assets, err := plugin.GetAvailableAssets()
-	if err == nil {
-		for _, asset := range assets {
-			console.Info(asset.Version)
+// Available displays versions matching selector, empty selector matches all versions of the default distribution
+func Available(ctx context.Context, plugin domain.Plugin, selector string, includePreReleases bool) error {
+	selector = resolveSelector(plugin, selector)
+	assets, err := ListAvailable(ctx, plugin, selectorDistribution(selector))
+	if err != nil {
+		return err
+	}
+	if domain.IsPluginChannel(plugin, selector) {
+		assets, err = domain.SelectChannel(selector, assets)
+		if err != nil {
+			return err
+		}
+		includePreReleases = includePreReleases || domain.IsPreReleaseChannel(assets)
+		selector = ""
+	}
+	constraint, err := domain.ParseConstraint(selector)
+	if err != nil {
+		return err
+	}
+	for _, asset := range assets {
+		version, err := domain.NewVersion(asset.Version)
+		if err == nil && version.IsPreRelease() && !includePreReleases {
+			continue
+		}
+		if selector != "" && (err != nil || !constraint.Matches(version)) {
+			continue
 		}
-	} else {
-		console.Error(err)
+		console.Info(asset.Version)
+	}
+	return nil
+}
+
+// ListAvailable lists assets of the distribution, empty distribution stands for the default one
+func ListAvailable(ctx context.Context, plugin domain.Plugin, distribution string) ([]domain.Asset, error) {
+	if distribution != "" {
+		distributions, ok := plugin.(domain.Distributions)
+		if !ok {
+			return nil, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "distribution " + distribution}
+		}
+		if distribution != distributions.DefaultDistribution() {
+			return distributions.GetDistributionAssets(ctx, distribution)
+		}
+	}
+	lister, ok := plugin.(domain.Lister)
+	if !ok {
+		return nil, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "listing available versions"}
 	}
+	return lister.GetAvailableAssets(ctx)
 }
@@ -0,0 +1,132 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const DefinitionFilePattern = "*.yaml"
+
+// Definition describes plugin in YAML, so new software can be supported without recompiling
+type Definition struct {
+	Name                   string                    `yaml:"name"`
+	LongName               string                    `yaml:"longName"`
+	Aliases                []string                  `yaml:"aliases"`
+	EnvNamePrefix          string                    `yaml:"envNamePrefix"`
+	EnvNameSuffix          string                    `yaml:"envNameSuffix"`
+	ExecutableRelativePath string                    `yaml:"executableRelativePath"`
+	VersionGranularity     domain.VersionGranularity `yaml:"versionGranularity"`
+	ExtractStrategy        domain.ExtractStrategy    `yaml:"extractStrategy"`
+	RawExecutableName      string                    `yaml:"rawExecutableName"`
+	// download url templates per "os/arch", "os" or "default"
+	DownloadUrl map[string]string `yaml:"downloadUrl"`
+	ChecksumUrl string            `yaml:"checksumUrl"`
+	Versions    *VersionsSource   `yaml:"versions"`
+	File        string            `yaml:"-"`
+}
+
+// VersionsSource is a page listing available versions, versions are taken by JSON path or by first group of HTML regex
+type VersionsSource struct {
+	Url      string `yaml:"url"`
+	JsonPath string `yaml:"jsonPath"`
+	Regex    string `yaml:"regex"`
+}
+
+func ReadDefinitions(dir string) ([]Definition, error) {
+	filePaths, err := filepath.Glob(filepath.Join(dir, DefinitionFilePattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filePaths)
+	definitions := make([]Definition, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		definition, err := parseDefinition(content)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", filePath, err)
+		}
+		definition.File = filePath
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+func parseDefinition(content []byte) (Definition, error) {
+	var definition Definition
+	err := yaml.Unmarshal(content, &definition)
+	if err != nil {
+		return Definition{}, err
+	}
+	if definition.Name == "" {
+		return Definition{}, errors.New("name is required")
+	}
+	if len(definition.DownloadUrl) == 0 {
+		return Definition{}, errors.New("downloadUrl is required")
+	}
+	if definition.LongName == "" {
+		definition.LongName = definition.Name
+	}
+	if definition.EnvNamePrefix == "" {
+		definition.EnvNamePrefix = strings.ToUpper(strings.ReplaceAll(definition.Name, "-", "_"))
+	}
+	if definition.EnvNameSuffix == "" {
+		definition.EnvNameSuffix = "_HOME"
+	}
+	switch definition.VersionGranularity {
+	case "":
+		definition.VersionGranularity = domain.VersionGranularityMajor
+	case domain.VersionGranularityMajor, domain.VersionGranularityMinor, domain.VersionGranularityPatch, domain.VersionGranularityFull:
+	default:
+		return Definition{}, fmt.Errorf("unsupported versionGranularity: %v", definition.VersionGranularity)
+	}
+	switch definition.ExtractStrategy {
+	case "":
+		definition.ExtractStrategy = domain.UseCompressedDirOrArchiveName
+	case domain.UseCompressedDirOrArchiveName, domain.ReplaceCompressedDirWithArchiveName:
+	default:
+		return Definition{}, fmt.Errorf("unsupported extractStrategy: %v", definition.ExtractStrategy)
+	}
+	if definition.RawExecutableName == "" {
+		definition.RawExecutableName = definition.Name
+	}
+	if definition.Versions != nil {
+		if definition.Versions.Url == "" {
+			return Definition{}, errors.New("versions.url is required")
+		}
+		if (definition.Versions.JsonPath == "") == (definition.Versions.Regex == "") {
+			return Definition{}, errors.New("exactly one of versions.jsonPath and versions.regex is required")
+		}
+	}
+	return definition, nil
+}
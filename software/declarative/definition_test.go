@@ -0,0 +1,131 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseDefinition(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       []string
+		want          Definition
+		expectedError string
+	}{
+		{
+			name: "defaults",
+			content: []string{
+				"name: terraform",
+				"downloadUrl:",
+				"  linux/amd64: https://releases.hashicorp.com/terraform/{{.Version}}/terraform_{{.Version}}_linux_amd64.zip",
+			},
+			want: Definition{
+				Name:               "terraform",
+				LongName:           "terraform",
+				EnvNamePrefix:      "TERRAFORM",
+				EnvNameSuffix:      "_HOME",
+				VersionGranularity: domain.VersionGranularityMajor,
+				ExtractStrategy:    domain.UseCompressedDirOrArchiveName,
+				RawExecutableName:  "terraform",
+				DownloadUrl: map[string]string{
+					"linux/amd64": "https://releases.hashicorp.com/terraform/{{.Version}}/terraform_{{.Version}}_linux_amd64.zip",
+				},
+			},
+		},
+		{
+			name: "all fields",
+			content: []string{
+				"name: gradle",
+				"longName: Gradle Build Tool",
+				"aliases: [gr]",
+				"envNamePrefix: GRADLE",
+				"envNameSuffix: _DIR",
+				"executableRelativePath: bin",
+				"versionGranularity: MINOR",
+				"extractStrategy: archive_replace",
+				"downloadUrl:",
+				"  default: https://services.gradle.org/distributions/gradle-{{.Version}}-bin.zip",
+				"checksumUrl: https://services.gradle.org/distributions/gradle-{{.Version}}-bin.zip.sha256",
+				"versions:",
+				"  url: https://services.gradle.org/versions/all",
+				"  jsonPath: '[].version'",
+			},
+			want: Definition{
+				Name:                   "gradle",
+				LongName:               "Gradle Build Tool",
+				Aliases:                []string{"gr"},
+				EnvNamePrefix:          "GRADLE",
+				EnvNameSuffix:          "_DIR",
+				ExecutableRelativePath: "bin",
+				VersionGranularity:     domain.VersionGranularityMinor,
+				ExtractStrategy:        domain.ReplaceCompressedDirWithArchiveName,
+				RawExecutableName:      "gradle",
+				DownloadUrl:            map[string]string{"default": "https://services.gradle.org/distributions/gradle-{{.Version}}-bin.zip"},
+				ChecksumUrl:            "https://services.gradle.org/distributions/gradle-{{.Version}}-bin.zip.sha256",
+				Versions:               &VersionsSource{Url: "https://services.gradle.org/versions/all", JsonPath: "[].version"},
+			},
+		},
+		{
+			name:          "missing name",
+			content:       []string{"downloadUrl:", "  default: https://example.com/x.zip"},
+			expectedError: "name is required",
+		},
+		{
+			name:          "missing download url",
+			content:       []string{"name: x"},
+			expectedError: "downloadUrl is required",
+		},
+		{
+			name:          "unsupported granularity",
+			content:       []string{"name: x", "versionGranularity: BUILD", "downloadUrl:", "  default: https://example.com/x.zip"},
+			expectedError: "unsupported versionGranularity: BUILD",
+		},
+		{
+			name: "both json path and regex",
+			content: []string{"name: x", "downloadUrl:", "  default: https://example.com/x.zip",
+				"versions:", "  url: https://example.com", "  jsonPath: a", "  regex: b"},
+			expectedError: "exactly one of versions.jsonPath and versions.regex is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDefinition([]byte(strings.Join(tt.content, "\n")))
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("parseDefinition() error = %v, want %v", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDefinition() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/util/web"
+	"io"
+	"regexp"
+	"strings"
+)
+
+func (source VersionsSource) list(ctx context.Context) ([]string, error) {
+	resp, err := web.Get(ctx, source.Url)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if source.JsonPath != "" {
+		return extractByJsonPath(content, source.JsonPath)
+	}
+	return extractByRegex(string(content), source.Regex)
+}
+
+// extractByJsonPath supports dot separated keys, "[]" iterates over array, e.g. "releases[].version"
+func extractByJsonPath(content []byte, jsonPath string) ([]string, error) {
+	var document any
+	err := json.Unmarshal(content, &document)
+	if err != nil {
+		return nil, err
+	}
+	values := []any{document}
+	for _, segment := range strings.Split(jsonPath, ".") {
+		key, iterate := strings.CutSuffix(segment, "[]")
+		next := make([]any, 0)
+		for _, value := range values {
+			if key != "" {
+				object, ok := value.(map[string]any)
+				if !ok {
+					continue
+				}
+				value, ok = object[key]
+				if !ok {
+					continue
+				}
+			}
+			if !iterate {
+				next = append(next, value)
+				continue
+			}
+			array, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%v is not an array", segment)
+			}
+			next = append(next, array...)
+		}
+		values = next
+	}
+	return distinct(values), nil
+}
+
+func extractByRegex(content, expression string) ([]string, error) {
+	regex, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	if regex.NumSubexp() < 1 {
+		return nil, fmt.Errorf("regex %v must have a group capturing version", expression)
+	}
+	values := make([]any, 0)
+	for _, match := range regex.FindAllStringSubmatch(content, -1) {
+		values = append(values, match[1])
+	}
+	return distinct(values), nil
+}
+
+func distinct(values []any) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		text, ok := value.(string)
+		if !ok || seen[text] {
+			continue
+		}
+		seen[text] = true
+		result = append(result, text)
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_extractByJsonPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		jsonPath string
+		want     []string
+	}{
+		{name: "array of objects", content: `[{"version":"1.0.0"},{"version":"1.1.0"}]`, jsonPath: "[].version", want: []string{"1.0.0", "1.1.0"}},
+		{name: "nested array", content: `{"data":{"releases":[{"v":"2.0"},{"v":"2.0"},{"v":"2.1"}]}}`, jsonPath: "data.releases[].v", want: []string{"2.0", "2.1"}},
+		{name: "array of strings", content: `{"versions":["3.1","3.2"]}`, jsonPath: "versions[]", want: []string{"3.1", "3.2"}},
+		{name: "missing key", content: `[{"name":"x"}]`, jsonPath: "[].version", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractByJsonPath([]byte(tt.content), tt.jsonPath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractByJsonPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractByRegex(t *testing.T) {
+	content := `<a href="tool_1.5.0.zip">tool_1.5.0.zip</a><a href="tool_1.6.1.zip">tool_1.6.1.zip</a>`
+	got, err := extractByRegex(content, `href="tool_([0-9.]+)\.zip"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"1.5.0", "1.6.1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractByRegex() = %v, want %v", got, want)
+	}
+
+	_, err = extractByRegex(content, `tool_[0-9.]+`)
+	if err == nil {
+		t.Errorf("extractByRegex() expected error for regex without group")
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/download"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+type templateData struct {
+	Version string
+	Major   int
+	Minor   int
+	Os      string
+	Arch    string
+}
+
+var templateFuncs = template.FuncMap{
+	"trimPrefix": strings.TrimPrefix,
+}
+
+type plugin struct {
+	domain.PluginInfo
+	definition Definition
+}
+
+type lister struct {
+	definition Definition
+}
+
+type checksumVerifier struct {
+	definition Definition
+}
+
+// Plugin has listing and checksum verification capabilities only if they are defined
+func (definition Definition) Plugin() domain.Plugin {
+	base := plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   definition.Name,
+			EnvNamePrefix:          definition.EnvNamePrefix,
+			EnvNameSuffix:          definition.EnvNameSuffix,
+			ExecutableRelativePath: definition.ExecutableRelativePath,
+			VersionGranularity:     definition.VersionGranularity,
+			ExtractStrategy:        definition.ExtractStrategy,
+			RawExecutableName:      definition.RawExecutableName,
+		},
+		definition: definition,
+	}
+	canList := definition.Versions != nil
+	canVerifyChecksum := definition.ChecksumUrl != ""
+	switch {
+	case canList && canVerifyChecksum:
+		return struct {
+			plugin
+			lister
+			checksumVerifier
+		}{base, lister{definition}, checksumVerifier{definition}}
+	case canList:
+		return struct {
+			plugin
+			lister
+		}{base, lister{definition}}
+	case canVerifyChecksum:
+		return struct {
+			plugin
+			checksumVerifier
+		}{base, checksumVerifier{definition}}
+	default:
+		return base
+	}
+}
+
+func (p plugin) CalculateDownloadUrl(version domain.Version, os, arch string) (string, domain.Type, error) {
+	downloadUrl, err := p.definition.downloadUrl(version, os, arch)
+	if err != nil {
+		return "", domain.UNKNOWN, err
+	}
+	return downloadUrl, toType(downloadUrl), nil
+}
+
+// CalculateDownloadedFileName keeps versions apart, download urls of unlisted software often end with the same file name
+func (p plugin) CalculateDownloadedFileName(asset domain.Asset) string {
+	return fmt.Sprintf("%v-%v-%v", p.definition.Name, asset.Version, fileName(asset.Url))
+}
+
+func (l lister) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	return l.definition.getAvailableAssets(ctx)
+}
+
+func (v checksumVerifier) VerifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	return v.definition.verifyChecksum(ctx, asset, fetchedPackage)
+}
+
+func (definition Definition) downloadUrl(version domain.Version, os, arch string) (string, error) {
+	for _, key := range []string{os + "/" + arch, os, "default"} {
+		urlTemplate, ok := definition.DownloadUrl[key]
+		if ok {
+			return executeTemplate(urlTemplate, version, os, arch)
+		}
+	}
+	return "", fmt.Errorf("%v is not available for %v/%v", definition.Name, os, arch)
+}
+
+func (definition Definition) getAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	versions, err := definition.Versions.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]domain.Asset, 0, len(versions))
+	for _, v := range versions {
+		version, err := domain.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		downloadUrl, err := definition.downloadUrl(version, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, newAsset(v, downloadUrl))
+	}
+	return assets, nil
+}
+
+func (definition Definition) verifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	checksumUrl, err := executeTemplate(definition.ChecksumUrl, fetchedPackage.Version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	downloadDir := filepath.Dir(fetchedPackage.FilePath)
+	checksumFileName := fmt.Sprintf("%v-%v-%v", definition.Name, fetchedPackage.Version.Value, fileName(checksumUrl))
+	checksumFilePath, err := download.FetchFileSilently(ctx, checksumUrl, downloadDir, checksumFileName)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(checksumFilePath)
+	if err != nil {
+		return err
+	}
+	expectedHash, err := findChecksum(string(content), fileName(asset.Url))
+	if err != nil {
+		return err
+	}
+	return verification.VerifySha256(fetchedPackage.FilePath, expectedHash)
+}
+
+// findChecksum takes either the only hash of the file or hash of the given file from sha256sum-like listing
+func findChecksum(content, fileName string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) == 1 && len(strings.Fields(lines[0])) == 1 {
+		return strings.TrimSpace(lines[0]), nil
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == fileName {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("checksum of %v not found", fileName)
+}
+
+func newAsset(version, downloadUrl string) domain.Asset {
+	return domain.Asset{
+		Name:    fileName(downloadUrl),
+		Version: version,
+		Url:     downloadUrl,
+		Type:    toType(downloadUrl),
+	}
+}
+
+func executeTemplate(text string, version domain.Version, os, arch string) (string, error) {
+	parsedTemplate, err := template.New("url").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	err = parsedTemplate.Execute(&buffer, templateData{
+		Version: version.Value,
+		Major:   version.Major(),
+		Minor:   version.Minor(),
+		Os:      os,
+		Arch:    arch,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func fileName(downloadUrl string) string {
+	parsedUrl, err := url.Parse(downloadUrl)
+	if err != nil {
+		return path.Base(downloadUrl)
+	}
+	return path.Base(parsedUrl.Path)
+}
+
+func toType(downloadUrl string) domain.Type {
+	name := fileName(downloadUrl)
+	if strings.HasSuffix(name, ".zip") {
+		return domain.ZIP
+	} else if strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") {
+		return domain.TAR_GZ
+	} else {
+		return domain.RAW
+	}
+}
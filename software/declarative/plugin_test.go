@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package declarative
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefinition_downloadUrl(t *testing.T) {
+	definition := Definition{
+		Name: "tool",
+		DownloadUrl: map[string]string{
+			"linux/amd64": "https://example.com/{{.Version}}/tool-{{.Os}}-x64.tar.gz",
+			"darwin":      "https://example.com/{{.Version}}/tool-mac-{{.Arch}}.zip",
+			"default":     "https://example.com/v{{.Major}}.{{.Minor}}/tool-{{trimPrefix .Version \"v\"}}",
+		},
+	}
+	tests := []struct {
+		name     string
+		version  string
+		os       string
+		arch     string
+		want     string
+		wantType domain.Type
+	}{
+		{name: "os and arch", version: "1.2.3", os: "linux", arch: "amd64", want: "https://example.com/1.2.3/tool-linux-x64.tar.gz", wantType: domain.TAR_GZ},
+		{name: "os", version: "1.2.3", os: "darwin", arch: "arm64", want: "https://example.com/1.2.3/tool-mac-arm64.zip", wantType: domain.ZIP},
+		{name: "default", version: "v1.2.3", os: "linux", arch: "arm64", want: "https://example.com/v1.2/tool-1.2.3", wantType: domain.RAW},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := domain.NewVersion(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, gotType, err := definition.Plugin().(domain.DownloadUrlCalculator).CalculateDownloadUrl(version, tt.os, tt.arch)
+			if err != nil || got != tt.want || gotType != tt.wantType {
+				t.Errorf("CalculateDownloadUrl() = %v, %v, %v, want %v, %v", got, gotType, err, tt.want, tt.wantType)
+			}
+		})
+	}
+
+	linuxOnly := Definition{Name: "tool", DownloadUrl: map[string]string{"linux": "https://example.com/{{.Version}}/tool.tar.gz"}}
+	version, err := domain.NewVersion("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = linuxOnly.Plugin().(domain.DownloadUrlCalculator).CalculateDownloadUrl(version, "windows", "amd64")
+	if err == nil || err.Error() != "tool is not available for windows/amd64" {
+		t.Errorf("CalculateDownloadUrl() error = %v, want not available for windows/amd64", err)
+	}
+}
+
+func TestDefinition_Plugin_capabilities(t *testing.T) {
+	downloadUrl := map[string]string{"default": "https://example.com/tool-{{.Version}}.zip"}
+	tests := []struct {
+		name                 string
+		definition           Definition
+		wantLister           bool
+		wantChecksumVerifier bool
+	}{
+		{name: "download only", definition: Definition{Name: "a", DownloadUrl: downloadUrl}},
+		{name: "listing", definition: Definition{Name: "b", DownloadUrl: downloadUrl, Versions: &VersionsSource{Url: "https://example.com", Regex: "(.*)"}}, wantLister: true},
+		{name: "checksum", definition: Definition{Name: "c", DownloadUrl: downloadUrl, ChecksumUrl: "https://example.com/sums"}, wantChecksumVerifier: true},
+		{name: "listing and checksum", definition: Definition{Name: "d", DownloadUrl: downloadUrl, ChecksumUrl: "https://example.com/sums", Versions: &VersionsSource{Url: "https://example.com", JsonPath: "[]"}}, wantLister: true, wantChecksumVerifier: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := tt.definition.Plugin()
+			if plugin.Info().Name != tt.definition.Name {
+				t.Errorf("Info().Name = %v, want %v", plugin.Info().Name, tt.definition.Name)
+			}
+			if _, ok := plugin.(domain.DownloadUrlCalculator); !ok {
+				t.Errorf("plugin is not DownloadUrlCalculator")
+			}
+			if _, ok := plugin.(domain.Lister); ok != tt.wantLister {
+				t.Errorf("plugin is Lister = %v, want %v", ok, tt.wantLister)
+			}
+			if _, ok := plugin.(domain.ChecksumVerifier); ok != tt.wantChecksumVerifier {
+				t.Errorf("plugin is ChecksumVerifier = %v, want %v", ok, tt.wantChecksumVerifier)
+			}
+			asset := domain.Asset{Name: tt.definition.Name, Version: "1.2.0", Url: "https://example.com/tool-1.2.0.zip"}
+			if got, want := domain.DownloadedFileName(plugin, asset), tt.definition.Name+"-1.2.0-tool-1.2.0.zip"; got != want {
+				t.Errorf("DownloadedFileName() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_findChecksum(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		want     string
+		wantErr  bool
+	}{
+		{name: "single hash", content: "abc123\n", fileName: "tool.zip", want: "abc123"},
+		{name: "sums file", content: "aaa  tool-linux.zip\nbbb *tool-mac.zip\n", fileName: "tool-mac.zip", want: "bbb"},
+		{name: "missing in sums file", content: "aaa  tool-linux.zip\nbbb  tool-mac.zip\n", fileName: "tool.zip", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findChecksum(tt.content, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("findChecksum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefinition_verifyChecksum(t *testing.T) {
+	content := []byte("archive")
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%x  tool_1.2.0_darwin.zip\n%x  tool_1.2.0_linux.zip\n", sha256.Sum256([]byte("other")), sha256.Sum256(content))
+	}))
+	defer svr.Close()
+	filePath := filepath.Join(t.TempDir(), "tool-1.2.0-tool_1.2.0_linux.zip")
+	err := os.WriteFile(filePath, content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	definition := Definition{Name: "tool", ChecksumUrl: svr.URL + "/tool_{{.Version}}_SHA256SUMS"}
+	// asset of unlisted software is named after the software
+	asset := domain.Asset{Name: "tool", Version: "1.2.0", Url: "https://example.com/1.2.0/tool_1.2.0_linux.zip"}
+
+	err = definition.verifyChecksum(context.Background(), asset, domain.FetchedPackage{Version: domain.Ver("1.2.0", t), FilePath: filePath})
+	if err != nil {
+		t.Errorf("verifyChecksum() error = %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/shell"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/viper"
+)
+
+func SetMain(plugin domain.Plugin, inputVersion string) error {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+
+	installedPackage, err := findInstalledPackage(installedPackages, inputVersion)
+	if err != nil {
+		return err
+	}
+	version := installedPackage.Version
+
+	err = installedPackages.SetMain(version)
+	if err != nil {
+		return err
+	}
+
+	err = config.StoreInstalledPackages(installedPackages)
+	if err != nil {
+		return err
+	}
+
+	finder := domain.ProdDirFinder{SoftwareDir: viper.GetString(config.SoftwareDirKey)}
+	_, err = shell.AddVariables(finder, installedPackages)
+	if err != nil {
+		return err
+	}
+
+	err = notifyEnvChange()
+	if err != nil {
+		return err
+	}
+
+	console.Info(plugin.Info().Name + " " + version.Value + " is now main version")
+	return nil
+}
+
+// notifyEnvChange lets plugins that bake environment variables into their artifacts (e.g. launchers) regenerate them,
+// it is called whenever installed packages of any software change
+func notifyEnvChange() error {
+	return RefreshLaunchers()
+}
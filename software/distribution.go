@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"regexp"
+)
+
+// resolveSelector resolves user defined alias and drops qualifier of the default distribution,
+// e.g. 17-zulu is the same as 17 for java
+func resolveSelector(plugin domain.Plugin, selector string) string {
+	selector = resolveAlias(plugin, selector)
+	distributions, ok := plugin.(domain.Distributions)
+	if !ok {
+		return selector
+	}
+	defaultQualifier := regexp.MustCompile(`-` + regexp.QuoteMeta(distributions.DefaultDistribution()) + `\b`)
+	return defaultQualifier.ReplaceAllString(selector, "")
+}
+
+// QualifySelector qualifies selector with distribution composed from options, e.g. 21 with vendor: tem, package: jre
+// becomes 21-tem-jre, every term of a constraint is qualified
+func QualifySelector(plugin domain.Plugin, selector string, options map[string]string) (string, error) {
+	if len(options) == 0 {
+		return selector, nil
+	}
+	distributions, ok := plugin.(domain.Distributions)
+	if !ok {
+		return "", &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "choosing distribution"}
+	}
+	distribution, err := distributions.Distribution(options)
+	if err != nil || distribution == "" {
+		return selector, err
+	}
+	if domain.IsPluginChannel(plugin, selector) || selectorDistribution(selector) != "" {
+		return "", fmt.Errorf("%v cannot be combined with distribution %v", selector, distribution)
+	}
+	if selector == "" {
+		return "-" + distribution, nil
+	}
+	constraint, err := domain.ParseConstraint(selector)
+	if err != nil {
+		return "", err
+	}
+	return constraint.Qualify(distribution)
+}
+
+// selectorDistribution names distribution the selector refers to, empty for the default one
+func selectorDistribution(selector string) string {
+	if domain.IsChannel(selector) {
+		return ""
+	}
+	constraint, err := domain.ParseConstraint(selector)
+	if err != nil {
+		return ""
+	}
+	return constraint.Distribution()
+}
+
+// packageDetailsOf names vendor and features of installed version, empty if software is built by single vendor
+func packageDetailsOf(plugin domain.Plugin, version domain.Version) (string, []string, error) {
+	distributions, ok := plugin.(domain.Distributions)
+	if !ok {
+		return "", nil, nil
+	}
+	return distributions.SplitDistribution(version.Distribution())
+}
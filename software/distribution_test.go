@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"testing"
+)
+
+func TestQualifySelector(t *testing.T) {
+	plugin := distributionsPlugin{listingPlugin: listingPlugin{directPlugin: directPlugin{PluginInfo: domain.PluginInfo{Name: "vendors"}}}}
+	tests := []struct {
+		selector string
+		options  map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{selector: "21", want: "21"},
+		{selector: "21", options: map[string]string{"vendor": ""}, want: "21"},
+		{selector: "21", options: map[string]string{"vendor": "tem"}, want: "21-tem"},
+		{selector: "", options: map[string]string{"vendor": "tem"}, want: "-tem"},
+		{selector: "20.", options: map[string]string{"vendor": "tem"}, want: "20-tem"},
+		{selector: ">=17 <21", options: map[string]string{"vendor": "tem"}, want: ">=17-tem <21-tem"},
+		{selector: "17 || 21", options: map[string]string{"vendor": "tem"}, want: "17-tem || 21-tem"},
+		{selector: "21.x", options: map[string]string{"vendor": "tem"}, want: "21-tem"},
+		{selector: "21.*", options: map[string]string{"vendor": "tem"}, want: "21-tem"},
+		{selector: "^21.0.1", options: map[string]string{"vendor": "tem"}, want: "^21.0.1-tem"},
+		{selector: "17.0.x || 21", options: map[string]string{"vendor": "tem"}, want: "17.0-tem || 21-tem"},
+		{selector: "21-ea", options: map[string]string{"vendor": "tem"}, wantErr: true},
+		{selector: "21-tem", options: map[string]string{"vendor": "corretto"}, wantErr: true},
+		{selector: "latest", options: map[string]string{"vendor": "tem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := QualifySelector(plugin, tt.selector, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QualifySelector() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("QualifySelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	_, err := QualifySelector(directPlugin{PluginInfo: domain.PluginInfo{Name: "direct"}}, "21", map[string]string{"vendor": "tem"})
+	if err == nil {
+		t.Errorf("QualifySelector() expected error for software with single distribution")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SessionExports prepares export lines switching given plugin to given installed version in the current shell only
+func SessionExports(plugin domain.Plugin, inputVersion string, cleanPath bool) ([]string, error) {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return nil, err
+	}
+	return sessionExports(installedPackages, inputVersion, os.Getenv("PATH"), cleanPath)
+}
+
+// sessionExports quotes every value, lines are evaluated by svm use shell function
+func sessionExports(installedPackages domain.InstalledPackages, inputVersion, pathValue string, cleanPath bool) ([]string, error) {
+	plugin := installedPackages.Plugin
+	installedPackage, err := findInstalledPackage(installedPackages, inputVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0)
+	if cleanPath {
+		pluginDir := filepath.Join(viper.GetString(config.SoftwareDirKey), plugin.Info().Name)
+		lines = append(lines, "export PATH="+domain.ShellQuote(domain.StripPathEntries(pathValue, pluginDir)))
+	}
+	lines = append(lines, domain.DirectEnvVariables(plugin, installedPackage).ToEvalExport()...)
+	return lines, nil
+}
+
+// findInstalledPackageInChannel asks lister which versions belong to the channel, e.g. lts,
+// and picks the highest installed one among them
+func findInstalledPackageInChannel(ctx context.Context, installedPackages domain.InstalledPackages, channel string) (domain.InstalledPackage, error) {
+	assets, err := ListAvailable(ctx, installedPackages.Plugin, "")
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	assets, err = domain.SelectChannel(channel, assets)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	inChannel := domain.InstalledPackages{Plugin: installedPackages.Plugin}
+	for _, item := range installedPackages.Items {
+		for _, asset := range assets {
+			if asset.Version == item.Version.Value {
+				inChannel.Items = append(inChannel.Items, item)
+				break
+			}
+		}
+	}
+	if len(inChannel.Items) == 0 {
+		return domain.InstalledPackage{}, &domain.NotInstalledError{Name: installedPackages.Plugin.Info().Name, Version: channel}
+	}
+	return findInstalledPackage(inChannel, "")
+}
+
+// FindSingleInstalledPackage finds installed package of the software with given version or the only one matching it
+func FindSingleInstalledPackage(plugin domain.Plugin, inputVersion string) (domain.InstalledPackage, error) {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	return findSingleInstalledPackage(installedPackages, inputVersion)
+}
+
+// findSingleInstalledPackage accepts version or constraint, e.g. 2023.3, matching exactly one installed version,
+// it fails instead of picking the highest one when more versions match
+func findSingleInstalledPackage(installedPackages domain.InstalledPackages, inputVersion string) (domain.InstalledPackage, error) {
+	if installedPackage, err := findExactInstalledPackage(installedPackages, inputVersion); err == nil {
+		return installedPackage, nil
+	}
+	constraint, err := domain.ParseConstraint(inputVersion)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	var matching []domain.InstalledPackage
+	var versions []string
+	for _, item := range installedPackages.Items {
+		if constraint.Matches(item.Version) {
+			matching = append(matching, item)
+			versions = append(versions, item.Version.Value)
+		}
+	}
+	switch len(matching) {
+	case 0:
+		return domain.InstalledPackage{}, &domain.NotInstalledError{Name: installedPackages.Plugin.Info().Name, Version: inputVersion, Candidates: installedPackages.Versions()}
+	case 1:
+		return matching[0], nil
+	default:
+		return domain.InstalledPackage{}, fmt.Errorf("version %s is ambiguous, it matches installed versions: %s", inputVersion, strings.Join(versions, ", "))
+	}
+}
+
+// findExactInstalledPackage accepts only installed version as it is, without resolving ranges, aliases or channels
+func findExactInstalledPackage(installedPackages domain.InstalledPackages, inputVersion string) (domain.InstalledPackage, error) {
+	version, err := domain.NewVersion(inputVersion)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	for _, item := range installedPackages.Items {
+		if item.Version == version {
+			return item, nil
+		}
+	}
+	return domain.InstalledPackage{}, &domain.NotInstalledError{Name: installedPackages.Plugin.Info().Name, Version: inputVersion, Candidates: installedPackages.Versions()}
+}
+
+// findInstalledPackage considers pre-releases too, they are installed on purpose and GA releases still take precedence
+func findInstalledPackage(installedPackages domain.InstalledPackages, inputVersion string) (domain.InstalledPackage, error) {
+	if len(installedPackages.Items) == 0 {
+		return domain.InstalledPackage{}, &domain.NotInstalledError{Name: installedPackages.Plugin.Info().Name}
+	}
+	inputVersion = resolveSelector(installedPackages.Plugin, inputVersion)
+	if domain.IsGenericChannel(inputVersion) {
+		inputVersion = ""
+	}
+	_, index, err := domain.FindVersion(inputVersion, installedPackages.Versions(), true)
+	var notFound *domain.VersionNotFoundError
+	if errors.As(err, &notFound) {
+		return domain.InstalledPackage{}, &domain.NotInstalledError{Name: installedPackages.Plugin.Info().Name, Version: inputVersion, Candidates: notFound.Candidates}
+	}
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	return installedPackages.Items[index], nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func Test_sessionExports_hostilePath(t *testing.T) {
+	pathValue := "/usr/bin:/tmp/a\"b:/tmp/$(touch pwned):/tmp/`id`:/tmp/it's"
+	installedPackages := domain.InstalledPackages{Plugin: domain.PluginInfo{Name: "node", EnvNamePrefix: "NODE", EnvNameSuffix: "_HOME", ExecutableRelativePath: "bin"}}
+	installedPackages.Add(domain.InstalledPackage{Version: domain.Ver("v20.1.3", t), Path: "/home/user/pf/node/node-$HOME-'v20'"})
+
+	lines, err := sessionExports(installedPackages, "20", pathValue, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	script := strings.Join(lines, "\n") + "\nprintf '%s\\n%s' \"$NODE_HOME\" \"$PATH\""
+	output, err := exec.Command("sh", "-c", script).Output()
+	if err != nil {
+		t.Fatalf("evaluating %q failed: %v", script, err)
+	}
+	want := "/home/user/pf/node/node-$HOME-'v20'\n/home/user/pf/node/node-$HOME-'v20'/bin:" + pathValue
+	if string(output) != want {
+		t.Errorf("evaluated exports = %q, want %q", string(output), want)
+	}
+}
+
+func Test_findSingleInstalledPackage(t *testing.T) {
+	installedPackages := domain.InstalledPackages{Plugin: domain.PluginInfo{Name: "intellij"}}
+	installedPackages.Add(domain.InstalledPackage{Version: domain.Ver("2023.3.6", t), Path: "/pf/intellij/2023.3.6"})
+	installedPackages.Add(domain.InstalledPackage{Version: domain.Ver("2024.1.1", t), Path: "/pf/intellij/2024.1.1"})
+	installedPackages.Add(domain.InstalledPackage{Version: domain.Ver("2024.1.2", t), Path: "/pf/intellij/2024.1.2"})
+
+	tests := []struct {
+		name         string
+		inputVersion string
+		wantPath     string
+		wantErr      string
+	}{
+		{name: "exact version", inputVersion: "2024.1.1", wantPath: "/pf/intellij/2024.1.1"},
+		{name: "single matching version", inputVersion: "2023.3", wantPath: "/pf/intellij/2023.3.6"},
+		{name: "ambiguous version", inputVersion: "2024.1", wantErr: "version 2024.1 is ambiguous, it matches installed versions: 2024.1.1, 2024.1.2"},
+		{name: "not installed version", inputVersion: "2022", wantErr: "Version 2022 is not installed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findSingleInstalledPackage(installedPackages, tt.inputVersion)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("findSingleInstalledPackage() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("findSingleInstalledPackage() = %v, want %v", got.Path, tt.wantPath)
+			}
+		})
+	}
+}
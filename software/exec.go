@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"context"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/shell"
+	"github.com/pkk82/soft-ver-man/util/process"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type ExecItem struct {
+	Plugin  domain.Plugin
+	Version string
+}
+
+// Exec runs command with environment of the given software versions, neither main versions nor the shell are touched
+func Exec(ctx context.Context, items []ExecItem, command string, args []string) (int, error) {
+	environ, err := execEnviron(ctx, items, os.Environ())
+	if err != nil {
+		return -1, err
+	}
+	executable, err := process.LookPath(command, environ)
+	if err != nil {
+		return -1, err
+	}
+	return process.Run(executable, args, environ)
+}
+
+func execEnviron(ctx context.Context, items []ExecItem, environ []string) ([]string, error) {
+	finder := domain.ProdDirFinder{SoftwareDir: viper.GetString(config.SoftwareDirKey)}
+	softDir, err := finder.SoftDir()
+	if err != nil {
+		return nil, err
+	}
+	softwareDirEnvVariable := shell.PrepareSvmSoftDirEnvVariable(softDir)
+	environ, err = domain.EnvVariables{Variables: []domain.EnvVariable{softwareDirEnvVariable}}.ToEnviron(environ)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		installedPackages, err := config.LoadInstalledPackages(item.Plugin.Info().Name)
+		if err != nil {
+			return nil, err
+		}
+		var installedPackage domain.InstalledPackage
+		version := resolveSelector(item.Plugin, item.Version)
+		if domain.IsPluginChannel(item.Plugin, version) && !domain.IsGenericChannel(version) {
+			installedPackage, err = findInstalledPackageInChannel(ctx, installedPackages, version)
+		} else {
+			installedPackage, err = findInstalledPackage(installedPackages, version)
+		}
+		if err != nil {
+			return nil, err
+		}
+		envVariables, err := installedPackages.PrepareEnvVariables(item.Plugin, config.VersionGranularity(item.Plugin.Info()))
+		if err != nil {
+			return nil, err
+		}
+		resolvedEnvVariables, err := envVariables.Resolve([]domain.EnvVariable{softwareDirEnvVariable})
+		if err != nil {
+			return nil, err
+		}
+		environ, err = resolvedEnvVariables.ToEnviron(environ)
+		if err != nil {
+			return nil, err
+		}
+		environ = stripPathEntries(environ, filepath.Join(softDir, item.Plugin.Info().Name))
+		environ, err = domain.DirectEnvVariables(item.Plugin, installedPackage).ToEnviron(environ)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return environ, nil
+}
+
+func stripPathEntries(environ []string, dir string) []string {
+	result := make([]string, len(environ))
+	for i, entry := range environ {
+		if value, ok := strings.CutPrefix(entry, "PATH="); ok {
+			entry = "PATH=" + domain.StripPathEntries(value, dir)
+		}
+		result[i] = entry
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// InfoCache keeps info responses of plugin executables between svm runs,
+// so that shims and hook-env do not run every plugin on each invocation.
+// Response is reused as long as the executable is not modified.
+type InfoCache struct {
+	path    string
+	entries map[string]cacheEntry
+	used    map[string]cacheEntry
+	changed bool
+}
+
+type cacheEntry struct {
+	ModTime  time.Time `json:"modTime"`
+	Size     int64     `json:"size"`
+	Response Response  `json:"response"`
+}
+
+// ReadInfoCache starts with empty cache if the file is missing or broken
+func ReadInfoCache(path string) *InfoCache {
+	cache := &InfoCache{path: path, entries: make(map[string]cacheEntry), used: make(map[string]cacheEntry)}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	entries := make(map[string]cacheEntry)
+	if json.Unmarshal(content, &entries) == nil {
+		cache.entries = entries
+	}
+	return cache
+}
+
+func (cache *InfoCache) get(executable string, stat os.FileInfo) (Response, bool) {
+	if cache == nil {
+		return Response{}, false
+	}
+	entry, ok := cache.entries[executable]
+	if !ok || !entry.ModTime.Equal(stat.ModTime()) || entry.Size != stat.Size() {
+		return Response{}, false
+	}
+	cache.used[executable] = entry
+	return entry.Response, true
+}
+
+func (cache *InfoCache) put(executable string, stat os.FileInfo, response Response) {
+	if cache == nil {
+		return
+	}
+	cache.used[executable] = cacheEntry{ModTime: stat.ModTime(), Size: stat.Size(), Response: response}
+	cache.changed = true
+}
+
+// Save writes responses used in this run only, entries of removed or modified executables are dropped
+func (cache *InfoCache) Save() error {
+	if !cache.changed && len(cache.used) == len(cache.entries) {
+		return nil
+	}
+	content, err := json.Marshal(cache.used)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(cache.path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cache.path, content, 0644)
+}
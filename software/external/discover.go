@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const ExecutablePrefix = "svm-plugin-"
+
+// Discover finds plugin executables in the given directories followed by directories of PATH,
+// executable found first wins if several share the name
+func Discover(dirs ...string) []string {
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+	found := make(map[string]bool)
+	executables := make([]string, 0)
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		names := make([]string, 0)
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ExecutablePrefix) && !found[entry.Name()] && isExecutable(filepath.Join(dir, entry.Name())) {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			found[name] = true
+			executables = append(executables, filepath.Join(dir, name))
+		}
+	}
+	return executables
+}
+
+func isExecutable(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir() && info.Mode()&0111 != 0
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// InfoTimeout limits info operation, it runs when svm starts, so hanging plugin would block every command
+const InfoTimeout = 5 * time.Second
+
+// Info describes plugin executable, it is the response to info operation
+type Info struct {
+	Name       string
+	LongName   string
+	Aliases    []string
+	Executable string
+	operations map[string]bool
+	response   Response
+}
+
+func (info Info) supports(operation string) bool {
+	return info.operations[operation]
+}
+
+// Load reuses info response from the cache if the executable has not changed, cache may be nil
+func Load(executable string, cache *InfoCache) (Info, error) {
+	stat, err := os.Stat(executable)
+	if err != nil {
+		return Info{}, err
+	}
+	response, cached := cache.get(executable, stat)
+	if !cached {
+		ctx, cancel := context.WithTimeout(context.Background(), InfoTimeout)
+		defer cancel()
+		response, err = call(ctx, executable, Request{Operation: OperationInfo})
+		if err != nil {
+			return Info{}, err
+		}
+	}
+	if response.Name == "" {
+		response.Name = strings.TrimPrefix(filepath.Base(executable), ExecutablePrefix)
+	}
+	if response.LongName == "" {
+		response.LongName = response.Name
+	}
+	if response.EnvNamePrefix == "" {
+		response.EnvNamePrefix = strings.ToUpper(strings.ReplaceAll(response.Name, "-", "_"))
+	}
+	if response.EnvNameSuffix == "" {
+		response.EnvNameSuffix = "_HOME"
+	}
+	switch domain.VersionGranularity(response.VersionGranularity) {
+	case "":
+		response.VersionGranularity = string(domain.VersionGranularityMajor)
+	case domain.VersionGranularityMajor, domain.VersionGranularityMinor, domain.VersionGranularityPatch, domain.VersionGranularityFull:
+	default:
+		return Info{}, fmt.Errorf("%v: unsupported versionGranularity: %v", filepath.Base(executable), response.VersionGranularity)
+	}
+	switch domain.ExtractStrategy(response.ExtractStrategy) {
+	case "":
+		response.ExtractStrategy = string(domain.UseCompressedDirOrArchiveName)
+	case domain.UseCompressedDirOrArchiveName, domain.ReplaceCompressedDirWithArchiveName:
+	default:
+		return Info{}, fmt.Errorf("%v: unsupported extractStrategy: %v", filepath.Base(executable), response.ExtractStrategy)
+	}
+	if response.RawExecutableName == "" {
+		response.RawExecutableName = response.Name
+	}
+	if !cached {
+		cache.put(executable, stat, response)
+	}
+	operations := make(map[string]bool)
+	for _, operation := range response.Operations {
+		operations[operation] = true
+	}
+	return Info{
+		Name:       response.Name,
+		LongName:   response.LongName,
+		Aliases:    response.Aliases,
+		Executable: executable,
+		operations: operations,
+		response:   response,
+	}, nil
+}
+
+type plugin struct {
+	domain.PluginInfo
+	info Info
+}
+
+type lister struct {
+	info Info
+}
+
+type checksumVerifier struct {
+	info Info
+}
+
+// Plugin has listing and checksum verification capabilities only if the executable supports them,
+// other optional operations fall back to defaults
+func (info Info) Plugin() domain.Plugin {
+	base := plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   info.Name,
+			EnvNamePrefix:          info.response.EnvNamePrefix,
+			EnvNameSuffix:          info.response.EnvNameSuffix,
+			ExecutableRelativePath: info.response.ExecutableRelativePath,
+			VersionGranularity:     domain.VersionGranularity(info.response.VersionGranularity),
+			ExtractStrategy:        domain.ExtractStrategy(info.response.ExtractStrategy),
+			RawExecutableName:      info.response.RawExecutableName,
+		},
+		info: info,
+	}
+	canList := info.supports(OperationList)
+	canVerifyChecksum := info.supports(OperationVerifyChecksum)
+	switch {
+	case canList && canVerifyChecksum:
+		return struct {
+			plugin
+			lister
+			checksumVerifier
+		}{base, lister{info}, checksumVerifier{info}}
+	case canList:
+		return struct {
+			plugin
+			lister
+		}{base, lister{info}}
+	case canVerifyChecksum:
+		return struct {
+			plugin
+			checksumVerifier
+		}{base, checksumVerifier{info}}
+	default:
+		return base
+	}
+}
+
+func (l lister) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	response, err := call(ctx, l.info.Executable, Request{Operation: OperationList})
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]domain.Asset, len(response.Assets))
+	for i, asset := range response.Assets {
+		assets[i] = toDomainAsset(asset)
+	}
+	return assets, nil
+}
+
+func (v checksumVerifier) VerifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	protocolAsset := toProtocolAsset(asset)
+	_, err := call(ctx, v.info.Executable, Request{
+		Operation: OperationVerifyChecksum,
+		Version:   fetchedPackage.Version.Value,
+		Asset:     &protocolAsset,
+		FilePath:  fetchedPackage.FilePath,
+	})
+	return err
+}
+
+func (p plugin) CalculateDownloadUrl(version domain.Version, os, arch string) (string, domain.Type, error) {
+	response, err := call(context.Background(), p.info.Executable, Request{Operation: OperationDownloadUrl, Version: version.Value, Os: os, Arch: arch})
+	if err != nil {
+		return "", domain.UNKNOWN, err
+	}
+	if response.Url == "" {
+		return "", domain.UNKNOWN, fmt.Errorf("%v %v returned no url", filepath.Base(p.info.Executable), OperationDownloadUrl)
+	}
+	switch response.Type {
+	case domain.ZIP, domain.TAR_GZ, domain.RAW:
+	default:
+		return "", domain.UNKNOWN, fmt.Errorf("%v %v returned unsupported type: %v", filepath.Base(p.info.Executable), OperationDownloadUrl, response.Type)
+	}
+	return response.Url, domain.Type(response.Type), nil
+}
+
+func (p plugin) CalculateDownloadedFileName(asset domain.Asset) string {
+	if !p.info.supports(OperationDownloadedFileName) {
+		return asset.Name
+	}
+	protocolAsset := toProtocolAsset(asset)
+	response, err := call(context.Background(), p.info.Executable, Request{Operation: OperationDownloadedFileName, Asset: &protocolAsset})
+	if err != nil || response.FileName == "" {
+		return asset.Name
+	}
+	return response.FileName
+}
+
+func (p plugin) ExtraVariables(homeDir string) domain.EnvVariables {
+	if !p.info.supports(OperationExtraVariables) {
+		return domain.EnvVariables{}
+	}
+	response, err := call(context.Background(), p.info.Executable, Request{Operation: OperationExtraVariables, HomeDir: homeDir})
+	if err != nil {
+		console.Error(err)
+		return domain.EnvVariables{}
+	}
+	variables := make([]domain.EnvVariable, len(response.Variables))
+	for i, variable := range response.Variables {
+		variables[i] = domain.EnvVariable{Name: variable.Name, SuffixValue: variable.Value}
+	}
+	return domain.EnvVariables{Variables: variables}
+}
+
+func (p plugin) PostInstall(installedPackage domain.InstalledPackage) error {
+	if !p.info.supports(OperationPostInstall) {
+		return nil
+	}
+	_, err := call(context.Background(), p.info.Executable, Request{
+		Operation:        OperationPostInstall,
+		InstalledPackage: &InstalledPackage{Version: installedPackage.Version.Value, Path: installedPackage.Path},
+	})
+	return err
+}
+
+func (p plugin) PostUninstall(version domain.Version) error {
+	if !p.info.supports(OperationPostUninstall) {
+		return nil
+	}
+	_, err := call(context.Background(), p.info.Executable, Request{Operation: OperationPostUninstall, Version: version.Value})
+	return err
+}
+
+func toDomainAsset(asset Asset) domain.Asset {
+	return domain.Asset{
+		Version:         asset.Version,
+		Name:            asset.Name,
+		Url:             asset.Url,
+		Type:            domain.Type(asset.Type),
+		ExtraProperties: asset.ExtraProperties,
+	}
+}
+
+func toProtocolAsset(asset domain.Asset) Asset {
+	return Asset{
+		Version:         asset.Version,
+		Name:            asset.Name,
+		Url:             asset.Url,
+		Type:            string(asset.Type),
+		ExtraProperties: asset.ExtraProperties,
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"context"
+	"errors"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var pluginScript = []string{
+	"#!/bin/sh",
+	"request=$(cat)",
+	"case \"$request\" in",
+	"  *'\"operation\":\"info\"'*)",
+	"    echo '{\"longName\":\"Internal Tool\",\"aliases\":[\"it\"],\"executableRelativePath\":\"bin\",\"operations\":[\"list\",\"downloadUrl\",\"extraVariables\"]}' ;;",
+	"  *'\"operation\":\"list\"'*)",
+	"    echo '{\"assets\":[{\"version\":\"1.2.0\",\"name\":\"tool-1.2.0.zip\",\"url\":\"https://example.com/tool-1.2.0.zip\",\"type\":\"zip\"}]}' ;;",
+	"  *'\"operation\":\"downloadUrl\"'*'\"version\":\"1.3.0\"'*)",
+	"    echo '{\"url\":\"https://example.com/tool-1.3.0.tar.gz\",\"type\":\"tar.gz\"}' ;;",
+	"  *'\"operation\":\"extraVariables\"'*)",
+	"    echo '{\"variables\":[{\"name\":\"TOOL_CACHE\",\"value\":\"/home/user/.tool\"}]}' ;;",
+	"  *)",
+	"    echo '{\"error\":\"unexpected request\"}' ;;",
+	"esac",
+}
+
+func createPlugin(dir, name string, t *testing.T) string {
+	test.CreateFile(dir, name, pluginScript, t)
+	executable := filepath.Join(dir, name)
+	err := os.Chmod(executable, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return executable
+}
+
+func TestLoad(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	executable := createPlugin(dir, "svm-plugin-internal-tool", t)
+
+	info, err := Load(executable, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Name != "internal-tool" || info.LongName != "Internal Tool" || !reflect.DeepEqual(info.Aliases, []string{"it"}) {
+		t.Errorf("Load() = %+v", info)
+	}
+
+	plugin := info.Plugin()
+	if plugin.Info().EnvNamePrefix != "INTERNAL_TOOL" || plugin.Info().EnvNameSuffix != "_HOME" || plugin.Info().ExecutableRelativePath != "bin" ||
+		plugin.Info().VersionGranularity != domain.VersionGranularityMajor {
+		t.Errorf("Plugin().Info() = %+v", plugin.Info())
+	}
+	if _, ok := plugin.(domain.ChecksumVerifier); ok {
+		t.Errorf("plugin not supporting verifyChecksum should not be ChecksumVerifier")
+	}
+
+	assets, err := plugin.(domain.Lister).GetAvailableAssets(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantAssets := []domain.Asset{{Version: "1.2.0", Name: "tool-1.2.0.zip", Url: "https://example.com/tool-1.2.0.zip", Type: domain.ZIP}}
+	if !reflect.DeepEqual(assets, wantAssets) {
+		t.Errorf("GetAvailableAssets() = %v, want %v", assets, wantAssets)
+	}
+
+	version, err := domain.NewVersion("1.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, urlType, err := plugin.(domain.DownloadUrlCalculator).CalculateDownloadUrl(version, "linux", "amd64")
+	if err != nil || url != "https://example.com/tool-1.3.0.tar.gz" || urlType != domain.TAR_GZ {
+		t.Errorf("CalculateDownloadUrl() = %v, %v, %v", url, urlType, err)
+	}
+
+	if fileName := domain.DownloadedFileName(plugin, wantAssets[0]); fileName != "tool-1.2.0.zip" {
+		t.Errorf("CalculateDownloadedFileName() = %v, want tool-1.2.0.zip", fileName)
+	}
+
+	wantVariables := domain.EnvVariables{Variables: []domain.EnvVariable{{Name: "TOOL_CACHE", SuffixValue: "/home/user/.tool"}}}
+	if variables := plugin.(domain.ExtraEnv).ExtraVariables("/home/user"); !reflect.DeepEqual(variables, wantVariables) {
+		t.Errorf("ExtraVariables() = %v, want %v", variables, wantVariables)
+	}
+
+	if err := plugin.(domain.InstallHook).PostUninstall(version); err != nil {
+		t.Errorf("PostUninstall() unexpected error: %v", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	firstDir := filepath.Join(dir, "first")
+	secondDir := filepath.Join(dir, "second")
+	test.CreateDir(firstDir, t)
+	test.CreateDir(secondDir, t)
+	createPlugin(firstDir, "svm-plugin-b", t)
+	createPlugin(secondDir, "svm-plugin-b", t)
+	createPlugin(secondDir, "svm-plugin-a", t)
+	createPlugin(secondDir, "other", t)
+	test.CreateFile(secondDir, "svm-plugin-c", []string{"not executable"}, t)
+	err := os.Chmod(filepath.Join(secondDir, "svm-plugin-c"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", secondDir)
+
+	got := Discover(firstDir)
+	want := []string{filepath.Join(firstDir, "svm-plugin-b"), filepath.Join(secondDir, "svm-plugin-a")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Discover() = %v, want %v", got, want)
+	}
+}
+
+func TestLoad_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		expectedError string
+	}{
+		{name: "unsupported granularity", response: `{"versionGranularity":"BUILD"}`, expectedError: "svm-plugin-tool: unsupported versionGranularity: BUILD"},
+		{name: "unsupported extract strategy", response: `{"extractStrategy":"flat"}`, expectedError: "svm-plugin-tool: unsupported extractStrategy: flat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := test.CreateTestDir(t)
+			test.CreateFile(dir, "svm-plugin-tool", []string{"#!/bin/sh", "cat > /dev/null", "echo '" + tt.response + "'"}, t)
+			executable := filepath.Join(dir, "svm-plugin-tool")
+			err := os.Chmod(executable, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = Load(executable, nil)
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("Load() error = %v, want %v", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestLoad_Cache(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	executable := createPlugin(dir, "svm-plugin-internal-tool", t)
+	cachePath := filepath.Join(dir, "cache", "plugins-info.json")
+
+	cache := ReadInfoCache(cachePath)
+	_, err := Load(executable, cache)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cache.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// executable cannot be run, info must come from the cache
+	err = os.Chmod(executable, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := Load(executable, ReadInfoCache(cachePath))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Name != "internal-tool" || info.LongName != "Internal Tool" || !info.supports(OperationList) {
+		t.Errorf("Load() = %+v", info)
+	}
+
+	// modified executable is called again
+	test.CreateFile(dir, "svm-plugin-internal-tool", append(pluginScript, "# modified"), t)
+	_, err = Load(executable, ReadInfoCache(cachePath))
+	if err == nil {
+		t.Errorf("Load() of modified executable should call it")
+	}
+}
+
+func TestCall_Timeout(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	test.CreateFile(dir, "svm-plugin-slow", []string{"#!/bin/sh", "exec sleep 10"}, t)
+	executable := filepath.Join(dir, "svm-plugin-slow")
+	err := os.Chmod(executable, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = call(ctx, executable, Request{Operation: OperationInfo})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call() took %v", elapsed)
+	}
+}
+
+func TestPlugin_CalculateDownloadUrl_invalidResponse(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		expectedError string
+	}{
+		{name: "error", response: `{"error":"1.3.0 is not published"}`, expectedError: "1.3.0 is not published"},
+		{name: "no url", response: `{"type":"zip"}`, expectedError: "svm-plugin-tool downloadUrl returned no url"},
+		{name: "unsupported type", response: `{"url":"https://example.com/tool.7z","type":"7z"}`, expectedError: "svm-plugin-tool downloadUrl returned unsupported type: 7z"},
+	}
+	version, err := domain.NewVersion("1.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := test.CreateTestDir(t)
+			test.CreateFile(dir, "svm-plugin-tool", []string{
+				"#!/bin/sh",
+				"case \"$(cat)\" in",
+				"  *'\"operation\":\"info\"'*) echo '{\"operations\":[\"downloadUrl\"]}' ;;",
+				"  *) echo '" + tt.response + "' ;;",
+				"esac",
+			}, t)
+			executable := filepath.Join(dir, "svm-plugin-tool")
+			err := os.Chmod(executable, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info, err := Load(executable, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			url, _, err := info.Plugin().(domain.DownloadUrlCalculator).CalculateDownloadUrl(version, "linux", "amd64")
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("CalculateDownloadUrl() = %v, error = %v, want %v", url, err, tt.expectedError)
+			}
+		})
+	}
+}
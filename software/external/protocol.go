@@ -0,0 +1,140 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const ProtocolVersion = 1
+
+const (
+	OperationInfo               = "info"
+	OperationList               = "list"
+	OperationDownloadUrl        = "downloadUrl"
+	OperationDownloadedFileName = "downloadedFileName"
+	OperationVerifyChecksum     = "verifyChecksum"
+	OperationExtraVariables     = "extraVariables"
+	OperationPostInstall        = "postInstall"
+	OperationPostUninstall      = "postUninstall"
+)
+
+// Request is written as JSON to stdin of plugin executable, only fields needed by the operation are set
+type Request struct {
+	ProtocolVersion  int               `json:"protocolVersion"`
+	Operation        string            `json:"operation"`
+	Version          string            `json:"version,omitempty"`
+	Os               string            `json:"os,omitempty"`
+	Arch             string            `json:"arch,omitempty"`
+	Asset            *Asset            `json:"asset,omitempty"`
+	FilePath         string            `json:"filePath,omitempty"`
+	HomeDir          string            `json:"homeDir,omitempty"`
+	InstalledPackage *InstalledPackage `json:"installedPackage,omitempty"`
+}
+
+// Response is read as JSON from stdout of plugin executable, non-empty error fails the operation
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	// info
+	Name                   string   `json:"name,omitempty"`
+	LongName               string   `json:"longName,omitempty"`
+	Aliases                []string `json:"aliases,omitempty"`
+	EnvNamePrefix          string   `json:"envNamePrefix,omitempty"`
+	EnvNameSuffix          string   `json:"envNameSuffix,omitempty"`
+	ExecutableRelativePath string   `json:"executableRelativePath,omitempty"`
+	VersionGranularity     string   `json:"versionGranularity,omitempty"`
+	ExtractStrategy        string   `json:"extractStrategy,omitempty"`
+	RawExecutableName      string   `json:"rawExecutableName,omitempty"`
+	Operations             []string `json:"operations,omitempty"`
+
+	// list
+	Assets []Asset `json:"assets,omitempty"`
+
+	// downloadUrl
+	Url  string `json:"url,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// downloadedFileName
+	FileName string `json:"fileName,omitempty"`
+
+	// extraVariables
+	Variables []Variable `json:"variables,omitempty"`
+}
+
+type Asset struct {
+	Version         string            `json:"version"`
+	Name            string            `json:"name"`
+	Url             string            `json:"url"`
+	Type            string            `json:"type"`
+	ExtraProperties map[string]string `json:"extraProperties,omitempty"`
+}
+
+type InstalledPackage struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func call(ctx context.Context, executable string, request Request) (Response, error) {
+	request.ProtocolVersion = ProtocolVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, err
+	}
+	var output bytes.Buffer
+	command := exec.CommandContext(ctx, executable)
+	command.Stdin = bytes.NewReader(input)
+	command.Stdout = &output
+	command.Stderr = console.Output()
+	// do not wait for output of processes started by the plugin after it is killed on timeout
+	command.WaitDelay = time.Second
+	err = command.Run()
+	if ctx.Err() != nil {
+		return Response{}, fmt.Errorf("%v %v failed: %w", filepath.Base(executable), request.Operation, ctx.Err())
+	}
+	if err != nil {
+		return Response{}, fmt.Errorf("%v %v failed: %w", filepath.Base(executable), request.Operation, err)
+	}
+	var response Response
+	err = json.Unmarshal(output.Bytes(), &response)
+	if err != nil {
+		return Response{}, fmt.Errorf("%v %v returned invalid response: %w", filepath.Base(executable), request.Operation, err)
+	}
+	if response.Error != "" {
+		return Response{}, errors.New(response.Error)
+	}
+	return response, nil
+}
@@ -23,6 +23,7 @@
 package software
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
 	"github.com/pkk82/soft-ver-man/util/download"
@@ -30,42 +31,83 @@ import (
 	"runtime"
 )
 
-func Fetch(plugin domain.Plugin, inputVersion, softwareDownloadDir string, verifyChecksum bool) (domain.FetchedPackage, error) {
-
-	var version domain.Version
-	var asset domain.Asset
+func Fetch(ctx context.Context, plugin domain.Plugin, inputVersion, softwareDownloadDir string, verifyChecksum, includePreReleases bool) (domain.FetchedPackage, error) {
+	version, asset, err := ResolveAsset(ctx, plugin, inputVersion, includePreReleases)
+	if err != nil {
+		return domain.FetchedPackage{}, err
+	}
+	return FetchAsset(ctx, plugin, version, asset, softwareDownloadDir, verifyChecksum)
+}
 
-	assets, err := plugin.GetAvailableAssets()
-	if err == nil {
+// ResolveAsset finds asset matching input version, alias or channel among available assets of plugin,
+// if plugin cannot list assets, download url of asset is calculated
+func ResolveAsset(ctx context.Context, plugin domain.Plugin, inputVersion string, includePreReleases bool) (domain.Version, domain.Asset, error) {
+	inputVersion = resolveSelector(plugin, inputVersion)
+	if _, ok := plugin.(domain.Lister); ok {
+		assets, err := ListAvailable(ctx, plugin, selectorDistribution(inputVersion))
+		if err != nil {
+			return domain.Version{}, domain.Asset{}, err
+		}
+		if domain.IsPluginChannel(plugin, inputVersion) {
+			assets, err = domain.SelectChannel(inputVersion, assets)
+			if err != nil {
+				return domain.Version{}, domain.Asset{}, err
+			}
+			includePreReleases = includePreReleases || domain.IsPreReleaseChannel(assets)
+			inputVersion = ""
+		}
 		versions := make([]string, len(assets))
 		for i, v := range assets {
 			versions[i] = v.Version
 		}
-		var index int
-		version, index, err = domain.FindVersion(inputVersion, versions)
+		version, index, err := domain.FindVersion(inputVersion, versions, includePreReleases)
 		if err != nil {
-			return domain.FetchedPackage{}, err
-		}
-		asset = assets[index]
-	} else {
-		version, err = domain.NewVersion(inputVersion)
-		if err != nil {
-			return domain.FetchedPackage{}, err
+			return domain.Version{}, domain.Asset{}, err
 		}
-		downloadUrl, extension := plugin.CalculateDownloadUrl(version, runtime.GOOS, runtime.GOARCH)
+		return version, assets[index], nil
+	}
 
-		asset = domain.Asset{Url: downloadUrl, Type: extension, Version: inputVersion, Name: plugin.Name}
+	calculator, ok := plugin.(domain.DownloadUrlCalculator)
+	if !ok {
+		return domain.Version{}, domain.Asset{}, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "download"}
+	}
+	if domain.IsPluginChannel(plugin, inputVersion) {
+		return domain.Version{}, domain.Asset{}, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "resolving channel " + inputVersion}
+	}
+	if distribution := selectorDistribution(inputVersion); distribution != "" {
+		return domain.Version{}, domain.Asset{}, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "distribution " + distribution}
+	}
+	if domain.IsConstraint(inputVersion) {
+		return domain.Version{}, domain.Asset{}, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "resolving version constraint " + inputVersion}
+	}
+	version, err := domain.NewVersion(inputVersion)
+	if err != nil {
+		return domain.Version{}, domain.Asset{}, err
 	}
+	downloadUrl, extension, err := calculator.CalculateDownloadUrl(version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return domain.Version{}, domain.Asset{}, err
+	}
+	return version, domain.Asset{Url: downloadUrl, Type: extension, Version: inputVersion, Name: plugin.Info().Name}, nil
+}
 
-	pluginDir := filepath.Join(softwareDownloadDir, plugin.Name)
+func FetchAsset(ctx context.Context, plugin domain.Plugin, version domain.Version, asset domain.Asset, softwareDownloadDir string, verifyChecksum bool) (domain.FetchedPackage, error) {
+	pluginDir := filepath.Join(softwareDownloadDir, plugin.Info().Name)
 
-	filename := plugin.CalculateDownloadedFileName(asset)
+	filename := domain.DownloadedFileName(plugin, asset)
 
-	fetchedPackagePath := download.FetchFile(asset.Url, pluginDir, filename)
+	fetchedPackagePath, err := download.FetchFile(ctx, asset.Url, pluginDir, filename)
+	if err != nil {
+		return domain.FetchedPackage{}, err
+	}
 	fetchedPackage := domain.FetchedPackage{Version: version, FilePath: fetchedPackagePath, Type: asset.Type}
 
 	if verifyChecksum {
-		err = plugin.VerifyChecksum(asset, fetchedPackage)
+		verifier, ok := plugin.(domain.ChecksumVerifier)
+		if !ok {
+			return domain.FetchedPackage{}, &domain.UnsupportedError{Name: plugin.Info().Name, Operation: "verify checksum"}
+		}
+		err := verifier.VerifyChecksum(ctx, asset, fetchedPackage)
 		if err != nil {
 			return domain.FetchedPackage{}, err
 		} else {
@@ -74,5 +116,4 @@ func Fetch(plugin domain.Plugin, inputVersion, softwareDownloadDir string, verif
 	}
 
 	return fetchedPackage, nil
-
 }
@@ -23,7 +23,7 @@
 package software
 
 import (
-	"errors"
+	"context"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/test"
 	"io"
@@ -58,6 +58,56 @@ func FileHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type directPlugin struct {
+	domain.PluginInfo
+	url string
+}
+
+func (p directPlugin) CalculateDownloadUrl(version domain.Version, os, arch string) (string, domain.Type, error) {
+	return p.url, domain.TAR_GZ, nil
+}
+
+func (p directPlugin) CalculateDownloadedFileName(asset domain.Asset) string {
+	return "artifact.tar.gz"
+}
+
+type listingPlugin struct {
+	directPlugin
+	assets []domain.Asset
+}
+
+func (p listingPlugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	return p.assets, nil
+}
+
+type distributionsPlugin struct {
+	listingPlugin
+	vendorAssets []domain.Asset
+}
+
+func (p distributionsPlugin) DefaultDistribution() string {
+	return "std"
+}
+
+func (p distributionsPlugin) Distribution(options map[string]string) (string, error) {
+	return options["vendor"], nil
+}
+
+func (p distributionsPlugin) SplitDistribution(distribution string) (string, []string, error) {
+	if distribution == "" {
+		return p.DefaultDistribution(), nil, nil
+	}
+	vendor, feature, found := strings.Cut(distribution, "-")
+	if !found {
+		return vendor, nil, nil
+	}
+	return vendor, []string{feature}, nil
+}
+
+func (p distributionsPlugin) GetDistributionAssets(ctx context.Context, distribution string) ([]domain.Asset, error) {
+	return p.vendorAssets, nil
+}
+
 func Test_fetch(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(FileHandler))
 	defer svr.Close()
@@ -75,17 +125,9 @@ func Test_fetch(t *testing.T) {
 		{
 			name: "direct fetch",
 			args: args{
-				plugin: domain.Plugin{
-					Name: "direct",
-					GetAvailableAssets: func() ([]domain.Asset, error) {
-						return []domain.Asset{}, errors.New("unsupported")
-					},
-					CalculateDownloadUrl: func(version domain.Version, os, arch string) (string, domain.Type) {
-						return svr.URL + "/artifacts/artifact.tar.gz", domain.TAR_GZ
-					},
-					CalculateDownloadedFileName: func(asset domain.Asset) string {
-						return "artifact.tar.gz"
-					},
+				plugin: directPlugin{
+					PluginInfo: domain.PluginInfo{Name: "direct"},
+					url:        svr.URL + "/artifacts/artifact.tar.gz",
 				},
 				inputVersion: "1.0.0",
 			},
@@ -99,18 +141,13 @@ func Test_fetch(t *testing.T) {
 		{
 			name: "assets fetch first",
 			args: args{
-				plugin: domain.Plugin{
-					Name: "asset",
-					GetAvailableAssets: func() ([]domain.Asset, error) {
-						return []domain.Asset{
-							{Version: "1.0.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"},
-						}, nil
+				plugin: listingPlugin{
+					directPlugin: directPlugin{
+						PluginInfo: domain.PluginInfo{Name: "asset"},
+						url:        svr.URL + "/artifacts/artifact.tar.gz",
 					},
-					CalculateDownloadUrl: func(version domain.Version, os, arch string) (string, domain.Type) {
-						return svr.URL + "/artifacts/artifact.tar.gz", domain.TAR_GZ
-					},
-					CalculateDownloadedFileName: func(asset domain.Asset) string {
-						return "artifact.tar.gz"
+					assets: []domain.Asset{
+						{Version: "1.0.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"},
 					},
 				},
 				inputVersion: "1.0.0",
@@ -122,16 +159,124 @@ func Test_fetch(t *testing.T) {
 				Type:     domain.TAR_GZ,
 			},
 		},
+		{
+			name: "lts channel",
+			args: args{
+				plugin: listingPlugin{
+					directPlugin: directPlugin{
+						PluginInfo: domain.PluginInfo{Name: "channel"},
+						url:        svr.URL + "/artifacts/artifact.tar.gz",
+					},
+					assets: []domain.Asset{
+						{Version: "2.0.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"},
+						{Version: "1.1.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz", Channels: []string{"lts", "lts/iron"}},
+						{Version: "1.0.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz", Channels: []string{"lts", "lts/iron"}},
+					},
+				},
+				inputVersion: "lts/iron",
+			},
+			wantErr: false,
+			want: domain.FetchedPackage{
+				Version:  domain.Ver("1.1.0", t),
+				FilePath: "channel/artifact.tar.gz",
+				Type:     domain.TAR_GZ,
+			},
+		},
+		{
+			name: "unknown lts codename",
+			args: args{
+				plugin: listingPlugin{
+					directPlugin: directPlugin{
+						PluginInfo: domain.PluginInfo{Name: "channel"},
+						url:        svr.URL + "/artifacts/artifact.tar.gz",
+					},
+					assets: []domain.Asset{
+						{Version: "1.0.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz", Channels: []string{"lts", "lts/iron"}},
+					},
+				},
+				inputVersion: "lts/hydrogen",
+			},
+			wantErr: true,
+		},
+		{
+			name: "channel without listing",
+			args: args{
+				plugin: directPlugin{
+					PluginInfo: domain.PluginInfo{Name: "direct"},
+					url:        svr.URL + "/artifacts/artifact.tar.gz",
+				},
+				inputVersion: "lts",
+			},
+			wantErr: true,
+		},
+		{
+			name: "vendor qualified version",
+			args: args{
+				plugin: distributionsPlugin{
+					listingPlugin: listingPlugin{
+						directPlugin: directPlugin{PluginInfo: domain.PluginInfo{Name: "vendors"}},
+						assets:       []domain.Asset{{Version: "1.1.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"}},
+					},
+					vendorAssets: []domain.Asset{{Version: "1.0.0-other", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"}},
+				},
+				inputVersion: "1-other",
+			},
+			want: domain.FetchedPackage{
+				Version:  domain.Ver("1.0.0-other", t),
+				FilePath: "vendors/artifact.tar.gz",
+				Type:     domain.TAR_GZ,
+			},
+		},
+		{
+			name: "default vendor qualified version",
+			args: args{
+				plugin: distributionsPlugin{
+					listingPlugin: listingPlugin{
+						directPlugin: directPlugin{PluginInfo: domain.PluginInfo{Name: "vendors"}},
+						assets:       []domain.Asset{{Version: "1.1.0", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"}},
+					},
+					vendorAssets: []domain.Asset{{Version: "1.0.0-other", Type: domain.TAR_GZ, Url: svr.URL + "/artifacts/artifact.tar.gz"}},
+				},
+				inputVersion: "1-std",
+			},
+			want: domain.FetchedPackage{
+				Version:  domain.Ver("1.1.0", t),
+				FilePath: "vendors/artifact.tar.gz",
+				Type:     domain.TAR_GZ,
+			},
+		},
+		{
+			name: "missing artifact",
+			args: args{
+				plugin: directPlugin{
+					PluginInfo: domain.PluginInfo{Name: "direct"},
+					url:        svr.URL + "/artifacts/missing.tar.gz",
+				},
+				inputVersion: "1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "download not supported",
+			args: args{
+				plugin:       domain.PluginInfo{Name: "info-only"},
+				inputVersion: "1.0.0",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			testDir := test.CreateTestDir(t)
-			got, err := Fetch(tt.args.plugin, tt.args.inputVersion, testDir, tt.args.verifyChecksum)
+			got, err := Fetch(context.Background(), tt.args.plugin, tt.args.inputVersion, testDir, tt.args.verifyChecksum, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetch() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			want := domain.FetchedPackage{Version: tt.want.Version, FilePath: path.Join(testDir, tt.want.FilePath), Type: tt.want.Type}
 			if !reflect.DeepEqual(got, want) {
 				t.Errorf("fetch() got = %v, want %v", got, want)
@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package golang
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"path/filepath"
+	"strings"
+)
+
+const goModFileName = "go.mod"
+
+func (plugin) DetectProjectVersion(dir string) (domain.ProjectVersion, bool, error) {
+	filePath := filepath.Join(dir, goModFileName)
+	exists, err := file.FileExists(filePath)
+	if err != nil {
+		return domain.ProjectVersion{}, false, err
+	}
+	if !exists {
+		return project.ReadVersionFile(dir, ".go-version")
+	}
+	content, err := file.ReadFile(filePath)
+	if err != nil {
+		return domain.ProjectVersion{}, false, err
+	}
+	version, found := parseGoMod(content)
+	if !found {
+		return domain.ProjectVersion{}, false, nil
+	}
+	return domain.ProjectVersion{Version: version, File: filePath}, true, nil
+}
+
+// parseGoMod finds toolchain directive or, if missing, go directive
+func parseGoMod(content string) (string, bool) {
+	goVersion := ""
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "toolchain":
+			version, _ := strings.CutPrefix(fields[1], "go")
+			return version, true
+		case "go":
+			goVersion = fields[1]
+		}
+	}
+	return goVersion, goVersion != ""
+}
@@ -22,6 +22,7 @@
 package golang
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
 	"os"
@@ -29,30 +30,24 @@ import (
 	"strings"
 )
 
+type plugin struct {
+	domain.PluginInfo
+}
+
 func init() {
-	var plugin = domain.Plugin{
-		Name:               Name,
-		EnvNamePrefix:      EnvNamePrefix,
-		EnvNameSuffix:      EnvNameSuffix,
-		GetAvailableAssets: getAvailableAssets,
-		VerifyChecksum:     verifyChecksum,
-		PostUninstall: func(version domain.Version) error {
-			return nil
-		},
-		ExtraVariables: calculateExtraVariables,
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return nil
+	domain.Register(plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   Name,
+			EnvNamePrefix:          EnvNamePrefix,
+			EnvNameSuffix:          EnvNameSuffix,
+			ExtractStrategy:        domain.ReplaceCompressedDirWithArchiveName,
+			ExecutableRelativePath: "bin",
+			VersionGranularity:     domain.VersionGranularityMinor,
 		},
-		CalculateDownloadedFileName: calculateDownloadFileName,
-		ExtractStrategy:             domain.ReplaceCompressedDirWithArchiveName,
-
-		ExecutableRelativePath: "bin",
-		VersionGranularity:     domain.VersionGranularityMinor,
-	}
-	domain.Register(plugin)
+	})
 }
 
-func calculateExtraVariables(homedir string) domain.EnvVariables {
+func (plugin) ExtraVariables(homedir string) domain.EnvVariables {
 	err := os.MkdirAll(path.Join(homedir, ".go"), 0755)
 	if err != nil {
 		console.Error(err)
@@ -66,12 +61,11 @@ func calculateExtraVariables(homedir string) domain.EnvVariables {
 	}
 }
 
-func calculateDownloadFileName(asset domain.Asset) string {
-	return asset.Name
-}
-
-func getAvailableAssets() ([]domain.Asset, error) {
-	packages := getSupportedPackages()
+func (plugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	packages, err := getSupportedPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
 	assets := make([]domain.Asset, len(packages))
 	for i, p := range packages {
 		version, _ := strings.CutPrefix(p.Version, "go")
@@ -82,10 +76,13 @@ func getAvailableAssets() ([]domain.Asset, error) {
 			Type:            p.Type,
 			ExtraProperties: map[string]string{"sha256": p.Sha256},
 		}
+		if p.Stable {
+			assets[i].Channels = []string{domain.ChannelStable}
+		}
 	}
 	return assets, nil
 }
 
-func verifyChecksum(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+func (plugin) VerifyChecksum(_ context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
 	return verifySha(fetchedPackage.FilePath, asset.ExtraProperties["sha256"])
 }
@@ -22,18 +22,20 @@
 package golang
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"io"
-	"net/http"
 	"runtime"
 	"strings"
 )
 
 type ApiPackagesPerVersion struct {
 	Version string       `json:"version"`
+	Stable  bool         `json:"stable"`
 	Files   []ApiPackage `json:"files"`
 }
 
@@ -53,25 +55,26 @@ type Package struct {
 	DownloadLink string
 	Type         domain.Type
 	Sha256       string
+	Stable       bool
 }
 
-func getSupportedPackages() []Package {
-	resp, err := http.Get(JsonFileURL)
+func getSupportedPackages(ctx context.Context) ([]Package, error) {
+	resp, err := web.Get(ctx, JsonFileURL)
 	if err != nil {
-		console.Fatal(err)
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			console.Fatal(err)
+			console.Error(err)
 		}
 	}(resp.Body)
 	var packagesPerVersion []ApiPackagesPerVersion
 	err = json.NewDecoder(resp.Body).Decode(&packagesPerVersion)
 	if err != nil {
-		console.Fatal(err)
+		return nil, err
 	}
-	return supportedPackages(&packagesPerVersion, runtime.GOOS, runtime.GOARCH)
+	return supportedPackages(&packagesPerVersion, runtime.GOOS, runtime.GOARCH), nil
 }
 
 func supportedPackages(packagesPerVersions *[]ApiPackagesPerVersion, goOpSystem, goarch string) []Package {
@@ -88,6 +91,7 @@ func supportedPackages(packagesPerVersions *[]ApiPackagesPerVersion, goOpSystem,
 					Type:         toType(apiPackage.Filename),
 					DownloadLink: fmt.Sprintf("%s/%s", DistURL, apiPackage.Filename),
 					Sha256:       apiPackage.Sha256,
+					Stable:       packagesPerVersion.Stable,
 				}
 				result = append(result, p)
 			}
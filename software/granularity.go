@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/shell"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/viper"
+)
+
+func DisplayVersionGranularity(plugin domain.Plugin) {
+	console.Info(fmt.Sprintf("%v (default: %v)", config.VersionGranularity(plugin.Info()), plugin.Info().VersionGranularity))
+}
+
+// SetVersionGranularity overrides granularity of versioned variables, e.g. PATCH for JAVA_17_0_9_HOME,
+// and regenerates rc file of the software
+func SetVersionGranularity(plugin domain.Plugin, value string) error {
+	granularity, err := domain.ParseVersionGranularity(value)
+	if err != nil {
+		return err
+	}
+	if granularity == config.VersionGranularity(plugin.Info()) {
+		return nil
+	}
+	err = config.StoreVersionGranularity(plugin.Info().Name, granularity)
+	if err != nil {
+		return err
+	}
+
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	if len(installedPackages.Items) == 0 {
+		return nil
+	}
+	finder := domain.ProdDirFinder{SoftwareDir: viper.GetString(config.SoftwareDirKey)}
+	_, err = shell.AddVariables(finder, installedPackages)
+	if err != nil {
+		return err
+	}
+	err = notifyEnvChange()
+	if err != nil {
+		return err
+	}
+	console.Info(fmt.Sprintf("%v variables regenerated with %v granularity", plugin.Info().Name, granularity))
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+)
+
+// HookExports prepares export lines switching every installed software to the version wanted by the project in dir,
+// software not wanted by the project is switched back to its main version
+func HookExports(dir string) ([]string, error) {
+	wantedVersions := findWantedVersions(dir)
+	allInstalledPackages, err := config.LoadAllInstalledPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	pathValue := os.Getenv("PATH")
+	lines := make([]string, 0)
+	for _, installedPackages := range allInstalledPackages {
+		if len(installedPackages.Items) == 0 {
+			continue
+		}
+		plugin := installedPackages.Plugin
+		installedPackage, err := wantedOrMain(installedPackages, wantedVersions[plugin.Info().Name])
+		if err != nil {
+			console.Warn(err)
+			continue
+		}
+		exports, err := exportsOf(installedPackages, installedPackage)
+		if err != nil {
+			console.Warn(err)
+			continue
+		}
+		pathValue = domain.StripPathEntries(pathValue, filepath.Join(viper.GetString(config.SoftwareDirKey), plugin.Info().Name))
+		lines = append(lines, exports...)
+	}
+	return append([]string{"export PATH=" + domain.ShellQuote(pathValue)}, lines...), nil
+}
+
+// findWantedVersions finds versions from project manifest (svm.yaml or .tool-versions)
+// and from files of the ecosystems, manifest wins; broken files are skipped with a warning,
+// so that the hook keeps working in every directory
+func findWantedVersions(dir string) map[string]string {
+	wantedVersions := make(map[string]string)
+	for _, detectedVersion := range project.Detect(dir) {
+		wantedVersions[detectedVersion.Plugin.Info().Name] = detectedVersion.ProjectVersion.Version
+	}
+	manifest, found, err := findManifestUp(dir)
+	if err != nil {
+		console.Warn(err)
+		return wantedVersions
+	}
+	if !found {
+		return wantedVersions
+	}
+	for _, tool := range manifest.Tools {
+		if plugin := domain.GetPlugin(tool.Name); plugin != nil {
+			selector, err := QualifySelector(plugin, tool.Version, tool.Options)
+			if err != nil {
+				console.Warn(fmt.Errorf("%v: %w", manifest.Path, err))
+				continue
+			}
+			wantedVersions[tool.Name] = selector
+		} else {
+			wantedVersions[tool.Name] = tool.Version
+		}
+	}
+	return wantedVersions
+}
+
+func findManifestUp(dir string) (project.Manifest, bool, error) {
+	currentDir := filepath.Clean(dir)
+	for {
+		manifest, found, err := project.FindManifest(currentDir)
+		if err != nil || found {
+			return manifest, found, err
+		}
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			return project.Manifest{}, false, nil
+		}
+		currentDir = parentDir
+	}
+}
+
+func wantedOrMain(installedPackages domain.InstalledPackages, wantedVersion string) (domain.InstalledPackage, error) {
+	if wantedVersion != "" {
+		installedPackage, err := findInstalledPackage(installedPackages, wantedVersion)
+		if err == nil {
+			return installedPackage, nil
+		}
+	}
+	mainPackage, err := installedPackages.FoundMain()
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	return *mainPackage, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"bytes"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_findManifestUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string][]string
+		dir           string
+		expectedFound bool
+		expectedTools []project.Tool
+	}{
+		{
+			name: "no manifest",
+			dir:  "a/b",
+		},
+		{
+			name:          "manifest in dir",
+			files:         map[string][]string{"a/b/svm.yaml": {"node: 20"}},
+			dir:           "a/b",
+			expectedFound: true,
+			expectedTools: []project.Tool{{Name: "node", Version: "20"}},
+		},
+		{
+			name:          "tool versions in parent dir",
+			files:         map[string][]string{"a/.tool-versions": {"nodejs 18.1.0"}},
+			dir:           "a/b",
+			expectedFound: true,
+			expectedTools: []project.Tool{{Name: "node", Version: "18.1.0"}},
+		},
+		{
+			name: "closest manifest wins",
+			files: map[string][]string{
+				"a/svm.yaml":   {"node: 18"},
+				"a/b/svm.yaml": {"node: 20"},
+			},
+			dir:           "a/b",
+			expectedFound: true,
+			expectedTools: []project.Tool{{Name: "node", Version: "20"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := test.CreateTestDir(t)
+			test.CreateDir(filepath.Join(root, tt.dir), t)
+			for fileName, content := range tt.files {
+				test.CreateFile(filepath.Join(root, filepath.Dir(fileName)), filepath.Base(fileName), content, t)
+			}
+			manifest, found, err := findManifestUp(filepath.Join(root, tt.dir))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("findManifestUp() found = %v, want %v", found, tt.expectedFound)
+			}
+			if found && !reflect.DeepEqual(manifest.Tools, tt.expectedTools) {
+				t.Errorf("findManifestUp() tools = %v, want %v", manifest.Tools, tt.expectedTools)
+			}
+		})
+	}
+}
+
+func Test_findWantedVersions_brokenManifest(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	test.CreateFile(dir, "svm.yaml", []string{"node: [20"}, t)
+	var output bytes.Buffer
+	console.SetOutput(&output)
+	defer console.SetOutput(os.Stderr)
+
+	got := findWantedVersions(dir)
+	if len(got) != 0 {
+		t.Errorf("findWantedVersions() = %v, want no versions", got)
+	}
+	if !strings.HasPrefix(output.String(), "Warning: ") {
+		t.Errorf("findWantedVersions() output = %q, want warning", output.String())
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/shell"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ImportAsdf adopts software installed by asdf (asdfDir/installs/<plugin>/<version>) as installed packages,
+// installations are linked in software directory, so nothing is downloaded again
+func ImportAsdf(asdfDir string) error {
+	configuration, err := config.Get()
+	if err != nil {
+		return err
+	}
+	installsDir := filepath.Join(asdfDir, "installs")
+	pluginDirs, err := os.ReadDir(installsDir)
+	if err != nil {
+		return err
+	}
+	for _, pluginDir := range pluginDirs {
+		plugin := domain.GetPlugin(project.FromAsdfName(pluginDir.Name()))
+		if !pluginDir.IsDir() || plugin == nil {
+			continue
+		}
+		err = importAsdfPlugin(plugin, filepath.Join(installsDir, pluginDir.Name()), configuration.SoftwareDir)
+		if err != nil {
+			return err
+		}
+	}
+	return reshimIfEnabled()
+}
+
+func importAsdfPlugin(plugin domain.Plugin, asdfPluginDir, softwareDir string) error {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	versionDirs, err := os.ReadDir(asdfPluginDir)
+	if err != nil {
+		return err
+	}
+	imported := 0
+	for _, versionDir := range versionDirs {
+		if !versionDir.IsDir() {
+			continue
+		}
+		version, err := domain.NewVersion(project.FromAsdfVersion(plugin.Info().Name, versionDir.Name()))
+		if err != nil {
+			console.Error(fmt.Errorf("skipping %v %v: %w", plugin.Info().Name, versionDir.Name(), err))
+			continue
+		}
+		if installedPackages.IsInstalled(version) {
+			continue
+		}
+		target := filepath.Join(asdfPluginDir, versionDir.Name(), project.AsdfHomeSubDir(plugin.Info().Name))
+		link := filepath.Join(softwareDir, plugin.Info().Name, "asdf-"+plugin.Info().Name+"-"+versionDir.Name())
+		err = os.MkdirAll(filepath.Dir(link), 0755)
+		if err != nil {
+			return err
+		}
+		err = os.Symlink(target, link)
+		if err != nil && !os.IsExist(err) {
+			return err
+		}
+		installedPackages.Add(domain.InstalledPackage{
+			Version:     version,
+			Path:        link,
+			InstalledOn: time.Now().UnixMilli(),
+			Main:        false,
+		})
+		console.Info(fmt.Sprintf("Imported %v %v from %v", plugin.Info().Name, version.Value, target))
+		imported++
+	}
+	if imported == 0 {
+		return nil
+	}
+
+	err = config.StoreInstalledPackages(installedPackages)
+	if err != nil {
+		return err
+	}
+	finder := domain.ProdDirFinder{SoftwareDir: viper.GetString(config.SoftwareDirKey)}
+	_, err = shell.AddVariables(finder, installedPackages)
+	if err != nil {
+		return err
+	}
+	return notifyEnvChange()
+}
@@ -23,9 +23,10 @@
 package software
 
 import (
-	"errors"
+	"context"
 	"github.com/pkk82/soft-ver-man/config"
 	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
 	"github.com/pkk82/soft-ver-man/shell"
 	"github.com/pkk82/soft-ver-man/util/archive"
 	"github.com/pkk82/soft-ver-man/util/console"
@@ -33,6 +34,7 @@ import (
 	"github.com/pkk82/soft-ver-man/util/file"
 	"github.com/spf13/viper"
 	"path"
+	"strings"
 	"time"
 )
 
@@ -41,23 +43,20 @@ type InstallOptions struct {
 	ArchivePath    *string
 	Main           *bool
 	Here           *bool
+	PreReleases    *bool
+	// Features of package given by ArchivePath, e.g. recorded in svm.lock, features qualified in version are used otherwise
+	Features []string
+	// PostInstall is run after the package is installed, e.g. to apply install flags of the software
+	PostInstall func(installedPackage domain.InstalledPackage) error
 }
 
-func Install(plugin domain.Plugin, inputVersion string, options InstallOptions) error {
+func Install(ctx context.Context, plugin domain.Plugin, inputVersion string, options InstallOptions) error {
 
-	version, err := domain.NewVersion(inputVersion)
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
 	if err != nil {
 		return err
 	}
-
-	installedPackages, err := config.LoadInstalledPackages(plugin.Name)
-	if err != nil {
-		return err
-	}
-
-	if installedPackages.IsInstalled(version) {
-		return errors.New("Version " + version.Value + " is already installed")
-	}
+	inputVersion = resolveSelector(plugin, inputVersion)
 
 	configuration, err := config.Get()
 	if err != nil {
@@ -65,22 +64,50 @@ func Install(plugin domain.Plugin, inputVersion string, options InstallOptions)
 	}
 
 	var fetchedPackage domain.FetchedPackage
-	if options.ArchivePath != nil {
-		archivePath := *options.ArchivePath
-		version, err = domain.NewVersion(inputVersion)
+	var features []string
+	var vendor string
+	if options.ArchivePath != nil && *options.ArchivePath != "" {
+		version, err := domain.NewVersion(inputVersion)
 		if err != nil {
-			console.Fatal(err)
+			return err
 		}
-
+		if installedPackages.IsInstalled(version) {
+			return &domain.AlreadyInstalledError{Name: plugin.Info().Name, Version: version.Value}
+		}
+		vendor, features, err = packageDetailsOf(plugin, version)
+		if err != nil {
+			return err
+		}
+		if len(options.Features) > 0 {
+			features = options.Features
+		}
+		archivePath := *options.ArchivePath
 		fetchedPackage = domain.FetchedPackage{
 			Version:  version,
 			FilePath: archivePath,
 			Type:     file.Extension(archivePath),
 		}
 	} else {
-		fetchedPackage, err = Fetch(plugin, inputVersion, configuration.SoftwareDownloadDir, *options.VerifyChecksum)
+		includePreReleases := options.PreReleases != nil && *options.PreReleases
+		version, asset, err := ResolveAsset(ctx, plugin, inputVersion, includePreReleases)
+		if err != nil {
+			return err
+		}
+		if installedPackages.IsInstalled(version) {
+			return &domain.AlreadyInstalledError{Name: plugin.Info().Name, Version: version.Value}
+		}
+		features = asset.Features
+		vendor = asset.ExtraProperties["vendor"]
+		if vendor == "" {
+			vendor, _, err = packageDetailsOf(plugin, version)
+			if err != nil {
+				return err
+			}
+		}
+		verifyChecksum := options.VerifyChecksum != nil && *options.VerifyChecksum
+		fetchedPackage, err = FetchAsset(ctx, plugin, version, asset, configuration.SoftwareDownloadDir, verifyChecksum)
 		if err != nil {
-			console.Fatal(err)
+			return err
 		}
 	}
 
@@ -91,7 +118,7 @@ func Install(plugin domain.Plugin, inputVersion string, options InstallOptions)
 
 	var installedPackage domain.InstalledPackage
 	if fetchedPackage.Type == domain.RAW {
-		copiedPackage, err := copy.Copy(fetchedPackage, path.Join(configuration.SoftwareDir, plugin.Name, plugin.Name+"-"+fetchedPackage.Version.Value), plugin.RawExecutableName)
+		copiedPackage, err := copy.Copy(fetchedPackage, path.Join(configuration.SoftwareDir, plugin.Info().Name, plugin.Info().Name+"-"+fetchedPackage.Version.Value), plugin.Info().RawExecutableName)
 		if err != nil {
 			return err
 		}
@@ -103,7 +130,7 @@ func Install(plugin domain.Plugin, inputVersion string, options InstallOptions)
 		}
 	} else {
 
-		extractedPackage, err := archive.Extract(fetchedPackage, path.Join(configuration.SoftwareDir, plugin.Name), plugin.ExtractStrategy)
+		extractedPackage, err := archive.Extract(fetchedPackage, path.Join(configuration.SoftwareDir, plugin.Info().Name), plugin.Info().ExtractStrategy)
 		if err != nil {
 			return err
 		}
@@ -114,6 +141,8 @@ func Install(plugin domain.Plugin, inputVersion string, options InstallOptions)
 			InstalledOn: time.Now().UnixMilli(),
 		}
 	}
+	installedPackage.Vendor = vendor
+	installedPackage.Features = strings.Join(features, ",")
 	installedPackages.Add(installedPackage)
 
 	err = config.StoreInstalledPackages(installedPackages)
@@ -136,16 +165,44 @@ func Install(plugin domain.Plugin, inputVersion string, options InstallOptions)
 		if err != nil {
 			return err
 		}
-		err = file.AppendInFile(".envrc", toHere.ToExport())
+		err = file.AppendInFile(".envrc", toHere.ToEvalExport())
+		if err != nil {
+			return err
+		}
+		err = updateToolVersions(".", plugin, installedPackage)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = plugin.PostInstall(installedPackage)
+	// package is already installed, so failing hook must not skip launchers and shims
+	if hook, ok := plugin.(domain.InstallHook); ok {
+		err = hook.PostInstall(installedPackage)
+		if err != nil {
+			console.Warn(err)
+		}
+	}
+
+	if options.PostInstall != nil {
+		err = options.PostInstall(installedPackage)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = registerLauncher(plugin, installedPackage)
 	if err != nil {
 		return err
 	}
+	err = notifyEnvChange()
+	if err != nil {
+		return err
+	}
+
+	return reshimIfEnabled()
+}
 
-	return nil
+// updateToolVersions keeps asdf .tool-versions of given directory in line with installed package, the file is created if missing
+func updateToolVersions(dir string, plugin domain.Plugin, installedPackage domain.InstalledPackage) error {
+	return project.WriteToolVersion(dir, plugin.Info().Name, installedPackage.Version.Value)
 }
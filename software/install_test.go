@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"testing"
+)
+
+func Test_updateToolVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     []string
+		wantContent []string
+	}{
+		{name: "file created", wantContent: []string{"nodejs 20.1.3", ""}},
+		{name: "file updated", content: []string{"java temurin-21.0.2", "nodejs 18.0.0", ""}, wantContent: []string{"java temurin-21.0.2", "nodejs 20.1.3", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := test.CreateTestDir(t)
+			if tt.content != nil {
+				test.CreateFile(dir, project.ToolVersionsFileName, tt.content, t)
+			}
+			installedPackage := domain.InstalledPackage{Version: domain.Ver("v20.1.3", t), Path: "/pf/node/v20.1.3"}
+			err := updateToolVersions(dir, domain.PluginInfo{Name: "node"}, installedPackage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			test.AssertFileContent(dir, project.ToolVersionsFileName, tt.wantContent, t)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+)
+
+// ConfigDir returns directory with settings of the IDE version, e.g. ~/.config/JetBrains/IntelliJIdea2024.1 on Linux
+// or its own directory if the version is isolated
+func ConfigDir(name string, installedPackage domain.InstalledPackage) (string, error) {
+	product, err := findProduct(name)
+	if err != nil {
+		return "", err
+	}
+	properties, err := isolatedProperties(installedPackage)
+	if err != nil {
+		return "", err
+	}
+	if configDir, ok := properties[configPathProperty]; ok {
+		return configDir, nil
+	}
+	jetBrainsDir, err := jetBrainsConfigDir(runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(jetBrainsDir, configDirName(product, installedPackage.Version)), nil
+}
+
+// ConfigDirs returns settings directories of installed versions of Java IDEs, several versions can share one directory
+func ConfigDirs() ([]string, error) {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, product := range Products {
+		if !product.java {
+			continue
+		}
+		installedPackages, err := config.LoadInstalledPackages(product.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, installedPackage := range installedPackages.Items {
+			dir, err := ConfigDir(product.Name, installedPackage)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+func configDirName(product Product, version domain.Version) string {
+	return fmt.Sprintf("%s%d.%d", product.configDirPrefix, version.Major(), version.Minor())
+}
+
+func jetBrainsConfigDir(goos string) (string, error) {
+	current, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	switch goos {
+	case "windows":
+		return filepath.Join(os.Getenv("APPDATA"), "JetBrains"), nil
+	case "darwin":
+		return filepath.Join(current.HomeDir, "Library", "Application Support", "JetBrains"), nil
+	default:
+		return filepath.Join(current.HomeDir, ".config", "JetBrains"), nil
+	}
+}
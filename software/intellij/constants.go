@@ -21,11 +21,12 @@
  */
 package intellij
 
-const DownloadURLPrefix = "https://download.jetbrains.com/idea"
+const DownloadURLPrefix = "https://download.jetbrains.com"
+const ReleasesAPIURL = "https://data.services.jetbrains.com/products/releases"
 
 const Name = "intellij-idea"
 const EnvNamePrefix = "INTELLIJ_IDEA"
 const EnvNameSuffix = "_HOME"
 const LongName = "Intellij IDEA Ultimate"
 
-var Aliases = []string{"intellij", "idea", "intellij-idea", "ii"}
+var Aliases = []string{"intellij", "idea", "ii"}
@@ -22,41 +22,52 @@
 package intellij
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 )
 
+type plugin struct {
+	domain.PluginInfo
+	product Product
+}
+
 func init() {
-	var plugin = domain.Plugin{
-		Name:          Name,
-		EnvNamePrefix: EnvNamePrefix,
-		EnvNameSuffix: EnvNameSuffix,
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return createLauncher(installedPackage)
-		},
-		PostUninstall: func(version domain.Version) error {
-			return deleteLauncher(version)
-		},
-		VerifyChecksum: func(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
-			return errors.New("verify checksum not supported")
-		},
-		GetAvailableAssets: func() ([]domain.Asset, error) {
-			return []domain.Asset{}, errors.New("get supported packages not supported")
-		},
-		CalculateDownloadUrl:        calculateDownloadUrl,
-		CalculateDownloadedFileName: calculateDownloadedFileName,
-		ExecutableRelativePath:      "bin",
-		VersionGranularity:          domain.VersionGranularityMajor,
-		ExtractStrategy:             domain.ReplaceCompressedDirWithArchiveName,
+	for _, product := range Products {
+		domain.Register(plugin{
+			PluginInfo: domain.PluginInfo{
+				Name:                   product.Name,
+				EnvNamePrefix:          product.EnvNamePrefix,
+				EnvNameSuffix:          EnvNameSuffix,
+				ExecutableRelativePath: "bin",
+				VersionGranularity:     domain.VersionGranularityMajor,
+				ExtractStrategy:        domain.ReplaceCompressedDirWithArchiveName,
+			},
+			product: product,
+		})
 	}
-	domain.Register(plugin)
 }
 
-func calculateDownloadUrl(version domain.Version, os string, arch string) (string, domain.Type) {
+func (p plugin) CreateLauncher(installedPackage domain.InstalledPackage, installedPackages domain.InstalledPackages) (string, error) {
+	return createLauncher(p.product, installedPackage, installedPackages)
+}
+
+func (p plugin) Channels() []string {
+	return []string{domain.ChannelRelease, domain.ChannelEap}
+}
+
+func (p plugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	return getAvailableAssets(ctx, p.product)
+}
+
+func (p plugin) VerifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	return verifyChecksum(ctx, asset, fetchedPackage)
+}
+
+func (p plugin) CalculateDownloadUrl(version domain.Version, os string, arch string) (string, domain.Type, error) {
 	extension := toExtension(os)
-	url := fmt.Sprintf("%s/ideaIU-%s%s.%s", DownloadURLPrefix, version.Value, toArch(os, arch), toExtension(os))
-	return url, extension
+	url := fmt.Sprintf("%s/%s/%s-%s%s.%s", DownloadURLPrefix, p.product.downloadDir, p.product.filePrefix, version.Value, toArch(os, arch), toExtension(os))
+	return url, extension, nil
 }
 
 func toExtension(os string) domain.Type {
@@ -79,6 +90,6 @@ func toArch(os string, arch string) string {
 	return ""
 }
 
-func calculateDownloadedFileName(asset domain.Asset) string {
-	return fmt.Sprintf("intellij-idea-ultimate-%s.%s", asset.Version, asset.Type)
+func (p plugin) CalculateDownloadedFileName(asset domain.Asset) string {
+	return fmt.Sprintf("%s-%s.%s", p.product.archiveName, asset.Version, asset.Type)
 }
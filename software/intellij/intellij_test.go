@@ -26,11 +26,12 @@ import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/software/intellij"
+	"path/filepath"
 	"testing"
 )
 
 func Test_calculateDownloadUrl(t *testing.T) {
-	plugin := domain.GetPlugin(intellij.Name)
+	plugin := domain.GetPlugin(intellij.Name).(domain.DownloadUrlCalculator)
 
 	tests := []struct {
 		version      domain.Version
@@ -77,7 +78,10 @@ func Test_calculateDownloadUrl(t *testing.T) {
 	for _, tt := range tests {
 		name := fmt.Sprintf("Checking download path for version %s, os %s and arch %s", tt.version.Value, tt.os, tt.arch)
 		t.Run(name, func(t *testing.T) {
-			actualPath, actualType := plugin.CalculateDownloadUrl(tt.version, tt.os, tt.arch)
+			actualPath, actualType, err := plugin.CalculateDownloadUrl(tt.version, tt.os, tt.arch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 			if actualPath != tt.expectedPath {
 				t.Errorf("calculateDownloadPath().path = %v, want %v", actualPath, tt.expectedPath)
 			}
@@ -87,3 +91,39 @@ func Test_calculateDownloadUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{name: intellij.Name, version: "2024.1", expected: "IntelliJIdea2024.1"},
+		{name: intellij.Name, version: "2023.3.6", expected: "IntelliJIdea2023.3"},
+		{name: "intellij-idea-community", version: "2024.1.2", expected: "IdeaIC2024.1"},
+		{name: "goland", version: "2024.1", expected: "GoLand2024.1"},
+	}
+	for _, tt := range tests {
+		dir, err := intellij.ConfigDir(tt.name, domain.InstalledPackage{Version: domain.Ver(tt.version, t), Path: t.TempDir()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Base(dir) != tt.expected {
+			t.Errorf("ConfigDir(%v, %v) = %v, want %v", tt.name, tt.version, dir, tt.expected)
+		}
+	}
+}
+
+func TestProducts(t *testing.T) {
+	for _, product := range intellij.Products {
+		for _, alias := range product.Aliases {
+			if alias == product.Name {
+				t.Errorf("%v has alias repeating its name", product.Name)
+			}
+		}
+		plugin := domain.GetPlugin(product.Name)
+		if !domain.IsPluginChannel(plugin, domain.ChannelRelease) || !domain.IsPluginChannel(plugin, domain.ChannelEap) {
+			t.Errorf("%v does not provide release and eap channels", product.Name)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/copy"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+const isolationSectionBegin = "# soft-ver-man isolated directories"
+const isolationSectionEnd = "# soft-ver-man isolated directories end"
+
+const (
+	configPathProperty  = "idea.config.path"
+	systemPathProperty  = "idea.system.path"
+	pluginsPathProperty = "idea.plugins.path"
+	logPathProperty     = "idea.log.path"
+)
+
+// Isolate makes the version keep its settings, caches, plugins and logs in its own directory,
+// e.g. ~/.soft-ver-man/intellij-idea/2024.1/config, so other versions cannot migrate or break them
+func Isolate(name string, installedPackage domain.InstalledPackage) error {
+	current, err := user.Current()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(current.HomeDir, config.HomeConfigDir, name, installedPackage.Version.Value)
+	propertiesPath := ideaPropertiesPath(installedPackage)
+	console.Info(fmt.Sprintf("Isolating directories of %s %s in %s", name, installedPackage.Version.Value, dir))
+	return file.ReplaceSection(propertiesPath, isolationSectionBegin, isolationSectionEnd, []string{
+		configPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "config")),
+		systemPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "system")),
+		pluginsPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "plugins")),
+		logPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "log")),
+	})
+}
+
+// CloneSettings copies settings (and plugins if both versions are isolated) from one version to another
+func CloneSettings(name string, from, to domain.InstalledPackage, force bool) error {
+	fromDirs, err := settingsDirs(name, from)
+	if err != nil {
+		return err
+	}
+	toDirs, err := settingsDirs(name, to)
+	if err != nil {
+		return err
+	}
+	for property, fromDir := range fromDirs {
+		toDir, ok := toDirs[property]
+		if !ok {
+			continue
+		}
+		if filepath.Clean(fromDir) == filepath.Clean(toDir) {
+			return fmt.Errorf("%s %s and %s share %s, isolate one of them first", name, from.Version.Value, to.Version.Value, fromDir)
+		}
+		exists, err := file.DirExists(fromDir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		entries, err := os.ReadDir(toDir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(entries) > 0 {
+			if !force {
+				return fmt.Errorf("%s is not empty, use --force to override it", toDir)
+			}
+			err = os.RemoveAll(toDir)
+			if err != nil {
+				return err
+			}
+		}
+		console.Info(fmt.Sprintf("Copying %s to %s", fromDir, toDir))
+		err = copy.CopyDir(fromDir, toDir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settingsDirs returns config directory of the version and its plugins directory if it is isolated
+func settingsDirs(name string, installedPackage domain.InstalledPackage) (map[string]string, error) {
+	configDir, err := ConfigDir(name, installedPackage)
+	if err != nil {
+		return nil, err
+	}
+	dirs := map[string]string{configPathProperty: configDir}
+	properties, err := isolatedProperties(installedPackage)
+	if err != nil {
+		return nil, err
+	}
+	if pluginsDir, ok := properties[pluginsPathProperty]; ok {
+		dirs[pluginsPathProperty] = pluginsDir
+	}
+	return dirs, nil
+}
+
+func ideaPropertiesPath(installedPackage domain.InstalledPackage) string {
+	return filepath.Join(installedPackage.Path, "bin", "idea.properties")
+}
+
+// isolatedProperties returns paths set by Isolate in idea.properties of the version
+func isolatedProperties(installedPackage domain.InstalledPackage) (map[string]string, error) {
+	properties := make(map[string]string)
+	propertiesPath := ideaPropertiesPath(installedPackage)
+	exists, err := file.FileExists(propertiesPath)
+	if err != nil || !exists {
+		return properties, err
+	}
+	content, err := file.ReadFile(propertiesPath)
+	if err != nil {
+		return nil, err
+	}
+	inSection := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == isolationSectionBegin:
+			inSection = true
+		case line == isolationSectionEnd:
+			inSection = false
+		case inSection:
+			if key, value, found := strings.Cut(line, "="); found {
+				properties[strings.TrimSpace(key)] = filepath.FromSlash(strings.TrimSpace(value))
+			}
+		}
+	}
+	return properties, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsolate(t *testing.T) {
+	installedPackage := domain.InstalledPackage{Version: domain.Ver("2024.1", t), Path: t.TempDir()}
+	propertiesPath := ideaPropertiesPath(installedPackage)
+	err := file.OverrideFileWithContent(propertiesPath, []string{"idea.max.intellisense.filesize=2500", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Isolate(Name, installedPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Isolate(Name, installedPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := file.ReadFile(propertiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(content, "idea.max.intellisense.filesize=2500\n") || strings.Count(content, isolationSectionBegin+"\n") != 1 {
+		t.Errorf("Isolate() content = %q", content)
+	}
+	configDir, err := ConfigDir(Name, installedPackage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(configDir, filepath.Join(Name, "2024.1", "config")) {
+		t.Errorf("ConfigDir() = %v", configDir)
+	}
+}
+
+func TestCloneSettings(t *testing.T) {
+	isolated := func(version string) domain.InstalledPackage {
+		installedPackage := domain.InstalledPackage{Version: domain.Ver(version, t), Path: t.TempDir()}
+		dir := t.TempDir()
+		err := file.ReplaceSection(ideaPropertiesPath(installedPackage), isolationSectionBegin, isolationSectionEnd, []string{
+			configPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "config")),
+			pluginsPathProperty + "=" + filepath.ToSlash(filepath.Join(dir, "plugins")),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return installedPackage
+	}
+	from := isolated("2023.3")
+	to := isolated("2024.1")
+	fromDirs, _ := settingsDirs(Name, from)
+	toDirs, _ := settingsDirs(Name, to)
+	err := file.OverrideFileWithContent(filepath.Join(fromDirs[configPathProperty], "options", "editor.xml"), []string{"<application/>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = file.OverrideFileWithContent(filepath.Join(fromDirs[pluginsPathProperty], "plugin", "lib.jar"), []string{"jar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = CloneSettings(Name, from, to, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, copied := range []string{filepath.Join(toDirs[configPathProperty], "options", "editor.xml"), filepath.Join(toDirs[pluginsPathProperty], "plugin", "lib.jar")} {
+		if _, err := os.Stat(copied); err != nil {
+			t.Errorf("CloneSettings() did not copy %v: %v", copied, err)
+		}
+	}
+
+	if err = CloneSettings(Name, from, to, false); err == nil {
+		t.Errorf("CloneSettings() expected error for not empty target")
+	}
+	if err = CloneSettings(Name, from, to, true); err != nil {
+		t.Errorf("CloneSettings() with force: %v", err)
+	}
+	if err = CloneSettings(Name, from, from, false); err == nil {
+		t.Errorf("CloneSettings() expected error for shared directory")
+	}
+}
@@ -28,44 +28,68 @@ import (
 	"github.com/pkk82/soft-ver-man/shell"
 	"github.com/pkk82/soft-ver-man/util/file"
 	"github.com/spf13/viper"
-	"os"
 	"os/user"
 	"path"
+	"regexp"
 	"runtime"
 	"strings"
 )
 
-func createLauncher(installedPackage domain.InstalledPackage) error {
+var nonActionChars = regexp.MustCompile(`[^A-Za-z0-9-]+`)
+
+func createLauncher(product Product, installedPackage domain.InstalledPackage, installedPackages domain.InstalledPackages) (string, error) {
 	current, err := user.Current()
 	if err != nil {
-		return err
+		return "", err
 	}
-	if runtime.GOOS == "linux" {
-		version := installedPackage.Version.Value
-		launcherFilename := "intellij-ultimate-" + version + ".desktop"
-		where := path.Join(current.HomeDir, ".local", "share", "applications", launcherFilename)
-		fmt.Printf("Creating launcher for Linux in %s\n", where)
+	if runtime.GOOS != "linux" {
+		return "", nil
+	}
+	version := installedPackage.Version.Value
+	launcherFilename := product.launcherName + "-" + version + ".desktop"
+	where := path.Join(current.HomeDir, ".local", "share", "applications", launcherFilename)
+	fmt.Printf("Creating launcher for Linux in %s\n", where)
 
-		envVariables, err := prepareEnvVariables()
-		if err != nil {
-			return err
-		}
+	envVariables, err := prepareEnvVariables()
+	if err != nil {
+		return "", err
+	}
 
-		err = file.OverrideFileWithContent(where, []string{
-			"[Desktop Entry]",
-			"Name=IU " + version,
-			"Comment=Intellij Ultimate " + version,
-			"Exec=" + envVariables + " " + path.Join(installedPackage.Path, "bin", "idea.sh"),
-			"Icon=" + path.Join(installedPackage.Path, "bin", "idea.png"),
-			"Terminal=false",
-			"Type=Application",
-		})
-		if err != nil {
-			return err
-		}
+	err = file.OverrideFileWithContent(where, desktopEntry(product, installedPackage, installedPackages, envVariables))
+	if err != nil {
+		return "", err
+	}
+	return where, nil
+}
 
+// desktopEntry describes launcher of the version with action opening any other installed version
+func desktopEntry(product Product, installedPackage domain.InstalledPackage, installedPackages domain.InstalledPackages, envVariables string) []string {
+	version := installedPackage.Version.Value
+	var actions []string
+	var actionLines []string
+	for _, item := range installedPackages.Items {
+		action := "version-" + nonActionChars.ReplaceAllString(item.Version.Value, "-")
+		actions = append(actions, action)
+		actionLines = append(actionLines,
+			"",
+			"[Desktop Action "+action+"]",
+			"Name="+product.shortName+" "+item.Version.Value,
+			"Exec="+envVariables+" "+path.Join(item.Path, "bin", product.executable+".sh"),
+		)
+	}
+	lines := []string{
+		"[Desktop Entry]",
+		"Name=" + product.shortName + " " + version,
+		"Comment=" + product.LongName + " " + version,
+		"Exec=" + envVariables + " " + path.Join(installedPackage.Path, "bin", product.executable+".sh"),
+		"Icon=" + path.Join(installedPackage.Path, "bin", product.executable+".png"),
+		"Terminal=false",
+		"Type=Application",
+	}
+	if len(actions) > 0 {
+		lines = append(lines, "Actions="+strings.Join(actions, ";")+";")
 	}
-	return nil
+	return append(lines, actionLines...)
 }
 
 func prepareEnvVariables() (string, error) {
@@ -83,7 +107,7 @@ func prepareEnvVariables() (string, error) {
 		if len(installedPackages.Items) == 0 {
 			continue
 		}
-		envVariables, err := installedPackages.PrepareEnvVariables(installedPackages.Plugin)
+		envVariables, err := installedPackages.PrepareEnvVariables(installedPackages.Plugin, config.VersionGranularity(installedPackages.Plugin.Info()))
 		if err != nil {
 			return "", err
 		}
@@ -110,22 +134,3 @@ func findSoftwareDirEnvVariable() (domain.EnvVariable, error) {
 	}
 	return shell.PrepareSvmSoftDirEnvVariable(softDir), nil
 }
-
-func deleteLauncher(version domain.Version) error {
-	current, err := user.Current()
-	if err != nil {
-		return err
-	}
-	if runtime.GOOS == "linux" {
-		versionValue := version.Value
-		launcherFilename := "intellij-ultimate-" + versionValue + ".desktop"
-		where := path.Join(current.HomeDir, ".local", "share", "applications", launcherFilename)
-		fmt.Printf("Deleting launcher for Linux in %s\n", where)
-
-		err := os.Remove(where)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
@@ -0,0 +1,81 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func TestDesktopEntry(t *testing.T) {
+	installedPackages := domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: domain.Ver("2024.1", t), Path: "/pf/intellij-idea/2024.1"},
+		{Version: domain.Ver("2023.3.6", t), Path: "/pf/intellij-idea/2023.3.6"},
+	}}
+	want := []string{
+		"[Desktop Entry]",
+		"Name=IU 2023.3.6",
+		"Comment=Intellij IDEA Ultimate 2023.3.6",
+		"Exec=env A=\"b\" /pf/intellij-idea/2023.3.6/bin/idea.sh",
+		"Icon=/pf/intellij-idea/2023.3.6/bin/idea.png",
+		"Terminal=false",
+		"Type=Application",
+		"Actions=version-2024-1;version-2023-3-6;",
+		"",
+		"[Desktop Action version-2024-1]",
+		"Name=IU 2024.1",
+		"Exec=env A=\"b\" /pf/intellij-idea/2024.1/bin/idea.sh",
+		"",
+		"[Desktop Action version-2023-3-6]",
+		"Name=IU 2023.3.6",
+		"Exec=env A=\"b\" /pf/intellij-idea/2023.3.6/bin/idea.sh",
+	}
+	if got := desktopEntry(Products[0], installedPackages.Items[1], installedPackages, "env A=\"b\""); !reflect.DeepEqual(got, want) {
+		t.Errorf("desktopEntry() = %v, want %v", got, want)
+	}
+}
+
+func TestDesktopEntryOfOtherProduct(t *testing.T) {
+	product, err := findProduct("goland")
+	if err != nil {
+		t.Fatal(err)
+	}
+	installedPackage := domain.InstalledPackage{Version: domain.Ver("2024.1", t), Path: "/pf/goland/2024.1"}
+	entry := desktopEntry(product, installedPackage, domain.InstalledPackages{Items: []domain.InstalledPackage{installedPackage}}, "env")
+	want := []string{"Name=GoLand 2024.1", "Exec=env /pf/goland/2024.1/bin/goland.sh", "Icon=/pf/goland/2024.1/bin/goland.png"}
+	for _, line := range want {
+		if !contains(entry, line) {
+			t.Errorf("desktopEntry() = %v, does not contain %v", entry, line)
+		}
+	}
+}
+
+func contains(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
+	"io"
+	"net/url"
+	"runtime"
+	"strings"
+)
+
+type Release struct {
+	Type      string                     `json:"type"`
+	Version   string                     `json:"version"`
+	Build     string                     `json:"build"`
+	Downloads map[string]ReleaseDownload `json:"downloads"`
+}
+
+type ReleaseDownload struct {
+	Link         string `json:"link"`
+	ChecksumLink string `json:"checksumLink"`
+}
+
+func getAvailableAssets(ctx context.Context, product Product) ([]domain.Asset, error) {
+	query := url.Values{"code": {product.Code}, "type": {"release,eap,rc"}}
+	resp, err := web.Get(ctx, ReleasesAPIURL+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			console.Error(err)
+		}
+	}(resp.Body)
+	var releases map[string][]Release
+	err = json.NewDecoder(resp.Body).Decode(&releases)
+	if err != nil {
+		return nil, err
+	}
+	return releaseAssets(releases[product.Code], runtime.GOOS, runtime.GOARCH), nil
+}
+
+// releaseAssets returns archives of releases for the platform, previews are versioned like 2024.2.eap10180
+// after the second part of their build number 242.10180.25
+func releaseAssets(releases []Release, goos, goarch string) []domain.Asset {
+	platform := toPlatform(goos, goarch)
+	assets := make([]domain.Asset, 0)
+	for _, release := range releases {
+		download, ok := release.Downloads[platform]
+		if !ok || download.Link == "" {
+			continue
+		}
+		assetType := toType(download.Link)
+		if assetType == domain.UNKNOWN {
+			continue
+		}
+		version := release.Version
+		channel := domain.ChannelRelease
+		if release.Type != "release" {
+			buildParts := strings.Split(release.Build, ".")
+			if len(buildParts) < 2 {
+				continue
+			}
+			version = fmt.Sprintf("%s.%s%s", release.Version, release.Type, buildParts[1])
+			channel = domain.ChannelEap
+		}
+		assets = append(assets, domain.Asset{
+			Version:         version,
+			Name:            download.Link[strings.LastIndex(download.Link, "/")+1:],
+			Url:             download.Link,
+			Type:            assetType,
+			ExtraProperties: map[string]string{"checksumLink": download.ChecksumLink, "build": release.Build},
+			Channels:        []string{channel},
+		})
+	}
+	return assets
+}
+
+func toPlatform(goos, goarch string) string {
+	switch {
+	case goos == "linux" && goarch == "arm64":
+		return "linuxARM64"
+	case goos == "linux":
+		return "linux"
+	case goos == "darwin" && goarch == "arm64":
+		return "macM1"
+	case goos == "darwin":
+		return "mac"
+	case goos == "windows" && goarch == "amd64":
+		return "windowsZip"
+	default:
+		return ""
+	}
+}
+
+func toType(link string) domain.Type {
+	switch {
+	case strings.HasSuffix(link, ".tar.gz"):
+		return domain.TAR_GZ
+	case strings.HasSuffix(link, ".zip"):
+		return domain.ZIP
+	case strings.HasSuffix(link, ".dmg"):
+		return domain.DMG
+	default:
+		return domain.UNKNOWN
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReleaseAssets(t *testing.T) {
+	releases := []Release{
+		{Type: "eap", Version: "2024.2", Build: "242.10180.25", Downloads: map[string]ReleaseDownload{
+			"linux": {Link: "https://download.jetbrains.com/idea/ideaIU-242.10180.25.tar.gz", ChecksumLink: "https://download.jetbrains.com/idea/ideaIU-242.10180.25.tar.gz.sha256"},
+		}},
+		{Type: "release", Version: "2024.1.2", Build: "241.17011.79", Downloads: map[string]ReleaseDownload{
+			"linux":      {Link: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.tar.gz", ChecksumLink: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.tar.gz.sha256"},
+			"windowsZip": {Link: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.win.zip", ChecksumLink: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.win.zip.sha256"},
+		}},
+		{Type: "release", Version: "2019.1", Build: "191.6183.87", Downloads: map[string]ReleaseDownload{
+			"windows": {Link: "https://download.jetbrains.com/idea/ideaIU-2019.1.exe"},
+		}},
+	}
+
+	tests := []struct {
+		goos   string
+		goarch string
+		want   []domain.Asset
+	}{
+		{goos: "linux", goarch: "amd64", want: []domain.Asset{
+			{Version: "2024.2.eap10180", Name: "ideaIU-242.10180.25.tar.gz", Url: "https://download.jetbrains.com/idea/ideaIU-242.10180.25.tar.gz", Type: domain.TAR_GZ,
+				ExtraProperties: map[string]string{"checksumLink": "https://download.jetbrains.com/idea/ideaIU-242.10180.25.tar.gz.sha256", "build": "242.10180.25"},
+				Channels:        []string{domain.ChannelEap}},
+			{Version: "2024.1.2", Name: "ideaIU-2024.1.2.tar.gz", Url: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.tar.gz", Type: domain.TAR_GZ,
+				ExtraProperties: map[string]string{"checksumLink": "https://download.jetbrains.com/idea/ideaIU-2024.1.2.tar.gz.sha256", "build": "241.17011.79"},
+				Channels:        []string{domain.ChannelRelease}},
+		}},
+		{goos: "windows", goarch: "amd64", want: []domain.Asset{
+			{Version: "2024.1.2", Name: "ideaIU-2024.1.2.win.zip", Url: "https://download.jetbrains.com/idea/ideaIU-2024.1.2.win.zip", Type: domain.ZIP,
+				ExtraProperties: map[string]string{"checksumLink": "https://download.jetbrains.com/idea/ideaIU-2024.1.2.win.zip.sha256", "build": "241.17011.79"},
+				Channels:        []string{domain.ChannelRelease}},
+		}},
+		{goos: "darwin", goarch: "arm64", want: []domain.Asset{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.goarch, func(t *testing.T) {
+			got := releaseAssets(releases, tt.goos, tt.goarch)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("releaseAssets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "ideaIU-2024.1.2.tar.gz")
+	content := []byte("archive")
+	err := os.WriteFile(filePath, content, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := fmt.Sprintf("%x *ideaIU-2024.1.2.tar.gz\n", sha256.Sum256(content))
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok.sha256" {
+			_, _ = w.Write([]byte(checksum))
+		} else {
+			_, _ = w.Write([]byte(fmt.Sprintf("%x *ideaIU-2024.1.2.tar.gz\n", sha256.Sum256([]byte("other")))))
+		}
+	}))
+	defer svr.Close()
+
+	tests := []struct {
+		checksumLink string
+		wantErr      bool
+	}{
+		{checksumLink: svr.URL + "/ok.sha256", wantErr: false},
+		{checksumLink: svr.URL + "/corrupted.sha256", wantErr: true},
+		{checksumLink: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		asset := domain.Asset{Name: "ideaIU-2024.1.2.tar.gz", ExtraProperties: map[string]string{"checksumLink": tt.checksumLink}}
+		err := verifyChecksum(context.Background(), asset, domain.FetchedPackage{FilePath: filePath})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("verifyChecksum(%v) error = %v, wantErr %v", tt.checksumLink, err, tt.wantErr)
+		}
+	}
+}
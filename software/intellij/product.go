@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import "fmt"
+
+// Product is JetBrains IDE, all of them are built on IntelliJ platform and share listing, launchers and settings handling
+type Product struct {
+	Name          string
+	LongName      string
+	Aliases       []string
+	EnvNamePrefix string
+	// Code identifies product in JetBrains releases API, e.g. IIU
+	Code string
+	// downloadDir and filePrefix build download url, e.g. idea/ideaIU-2024.1.tar.gz
+	downloadDir string
+	filePrefix  string
+	// archiveName names downloaded file and launcher, e.g. intellij-idea-ultimate-2024.1.tar.gz
+	archiveName string
+	// launcherName names desktop launcher file, e.g. intellij-ultimate-2024.1.desktop
+	launcherName string
+	// shortName is shown in launcher names, e.g. IU 2024.1
+	shortName string
+	// executable is script and icon name in bin directory, e.g. idea.sh and idea.png
+	executable string
+	// configDirPrefix names settings directory, e.g. IntelliJIdea2024.1
+	configDirPrefix string
+	// java tells whether IDE develops Java, so installed JDKs are registered in it
+	java bool
+}
+
+var Products = []Product{
+	{Name: Name, LongName: LongName, Aliases: Aliases, EnvNamePrefix: EnvNamePrefix, Code: "IIU",
+		downloadDir: "idea", filePrefix: "ideaIU", archiveName: "intellij-idea-ultimate", launcherName: "intellij-ultimate", shortName: "IU",
+		executable: "idea", configDirPrefix: "IntelliJIdea", java: true},
+	{Name: "intellij-idea-community", LongName: "Intellij IDEA Community", Aliases: []string{"intellij-community", "idea-community", "iic"},
+		EnvNamePrefix: "INTELLIJ_IDEA_COMMUNITY", Code: "IIC",
+		downloadDir: "idea", filePrefix: "ideaIC", archiveName: "intellij-idea-community", launcherName: "intellij-community", shortName: "IC",
+		executable: "idea", configDirPrefix: "IdeaIC", java: true},
+	{Name: "goland", LongName: "GoLand", EnvNamePrefix: "GOLAND", Code: "GO",
+		downloadDir: "go", filePrefix: "goland", archiveName: "goland", launcherName: "goland", shortName: "GoLand",
+		executable: "goland", configDirPrefix: "GoLand"},
+	{Name: "pycharm", LongName: "PyCharm Professional", Aliases: []string{"pycharm-professional"}, EnvNamePrefix: "PYCHARM", Code: "PCP",
+		downloadDir: "python", filePrefix: "pycharm-professional", archiveName: "pycharm-professional", launcherName: "pycharm-professional", shortName: "PyCharm",
+		executable: "pycharm", configDirPrefix: "PyCharm"},
+	{Name: "webstorm", LongName: "WebStorm", Aliases: []string{"ws"}, EnvNamePrefix: "WEBSTORM", Code: "WS",
+		downloadDir: "webstorm", filePrefix: "WebStorm", archiveName: "webstorm", launcherName: "webstorm", shortName: "WebStorm",
+		executable: "webstorm", configDirPrefix: "WebStorm"},
+	{Name: "datagrip", LongName: "DataGrip", Aliases: []string{"dg"}, EnvNamePrefix: "DATAGRIP", Code: "DG",
+		downloadDir: "datagrip", filePrefix: "datagrip", archiveName: "datagrip", launcherName: "datagrip", shortName: "DataGrip",
+		executable: "datagrip", configDirPrefix: "DataGrip"},
+	{Name: "rider", LongName: "Rider", EnvNamePrefix: "RIDER", Code: "RD",
+		downloadDir: "rider", filePrefix: "JetBrains.Rider", archiveName: "rider", launcherName: "rider", shortName: "Rider",
+		executable: "rider", configDirPrefix: "Rider"},
+}
+
+func findProduct(name string) (Product, error) {
+	for _, product := range Products {
+		if product.Name == name {
+			return product, nil
+		}
+	}
+	return Product{}, fmt.Errorf("%v is not a JetBrains IDE", name)
+}
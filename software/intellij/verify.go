@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package intellij
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"github.com/pkk82/soft-ver-man/util/web"
+	"io"
+	"strings"
+)
+
+// verifyChecksum compares sha256 of the file with the one published next to it, e.g. ideaIU-2024.1.tar.gz.sha256
+func verifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	checksumLink := asset.ExtraProperties["checksumLink"]
+	if checksumLink == "" {
+		return errors.New("no checksum published for " + asset.Name)
+	}
+	resp, err := web.Get(ctx, checksumLink)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			console.Error(err)
+		}
+	}(resp.Body)
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return fmt.Errorf("empty checksum at %s", checksumLink)
+	}
+	err = verification.VerifySha256(fetchedPackage.FilePath, fields[0])
+	if err != nil {
+		return fmt.Errorf("%s is corrupted file: %w", fetchedPackage.FilePath, err)
+	}
+	console.Info(fetchedPackage.FilePath + " is correct file")
+	return nil
+}
@@ -23,11 +23,16 @@
 package java
 
 const PackagesAPIURL = "https://api.azul.com/metadata/v1/zulu/packages"
+const TemurinAPIURL = "https://api.adoptium.net/v3/assets/version/%5B8%2C100%29"
+const FoojayPackagesAPIURL = "https://api.foojay.io/disco/v3.0/packages"
+const FoojayIdsAPIURL = "https://api.foojay.io/disco/v3.0/ids/"
+const GraalceReleasesURL = "https://api.github.com/repos/graalvm/graalvm-ce-builds/releases"
+const LibericaAPIURL = "https://api.bell-sw.com/v1/liberica/releases"
 
 const PageSize = 1000
 
 const Name = "java"
-const LongName = "JDK (Azul Zulu)"
+const LongName = "JDK (Azul Zulu, Eclipse Temurin, Amazon Corretto, GraalVM CE, BellSoft Liberica)"
 const EnvPrefix = "JAVA"
 const EnvSuffix = "_HOME"
 
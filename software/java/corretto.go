@@ -0,0 +1,104 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"runtime"
+	"strings"
+)
+
+// Amazon publishes no listing API of Corretto, so packages are listed by foojay Disco API
+
+type FoojayResponse[T any] struct {
+	Result []T `json:"result"`
+}
+
+type FoojayPackage struct {
+	Id                string `json:"id"`
+	Filename          string `json:"filename"`
+	JavaVersion       string `json:"java_version"`
+	DirectDownloadUri string `json:"direct_download_uri"`
+}
+
+type FoojayPackageInfo struct {
+	Checksum     string `json:"checksum"`
+	ChecksumType string `json:"checksum_type"`
+}
+
+func getCorrettoAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	url := FoojayPackagesAPIURL + "?distribution=corretto" +
+		"&package_type=" + f.packageType() +
+		"&release_status=ga" +
+		"&javafx_bundled=false" +
+		"&operating_system=" + toFoojayOs(runtime.GOOS) +
+		"&architecture=" + toTemurinArch(runtime.GOARCH) +
+		"&archive_type=" + toType(runtime.GOOS)
+	if runtime.GOOS == "linux" && f.musl {
+		url += "&lib_c_type=musl"
+	} else if runtime.GOOS == "linux" {
+		url += "&lib_c_type=glibc"
+	}
+	var response FoojayResponse[FoojayPackage]
+	err := getJson(ctx, url, &response)
+	if err != nil {
+		return nil, err
+	}
+	return correttoAssets(response.Result, f), nil
+}
+
+func correttoAssets(packages []FoojayPackage, f flavour) []domain.Asset {
+	assets := make([]domain.Asset, 0, len(packages))
+	seen := make(map[string]bool)
+	for _, p := range packages {
+		version, _, _ := strings.Cut(p.JavaVersion, "+")
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+		assets = append(assets, f.asset(p.Filename, version, p.DirectDownloadUri, map[string]string{"packageId": p.Id}))
+	}
+	return assets
+}
+
+func verifyCorrettoChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	var response FoojayResponse[FoojayPackageInfo]
+	err := getJson(ctx, FoojayIdsAPIURL+asset.ExtraProperties["packageId"], &response)
+	if err != nil {
+		return err
+	}
+	if len(response.Result) == 0 || !strings.EqualFold(response.Result[0].ChecksumType, "sha256") {
+		return fmt.Errorf("no sha256 checksum of %v", asset.Name)
+	}
+	return verification.VerifySha256(fetchedPackage.FilePath, response.Result[0].Checksum)
+}
+
+func toFoojayOs(goOpSystem string) string {
+	if goOpSystem == "darwin" {
+		return "macos"
+	}
+	return goOpSystem
+}
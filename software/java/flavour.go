@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	featureJre    = "jre"
+	featureJavafx = "javafx"
+	featureCrac   = "crac"
+	featureMusl   = "musl"
+)
+
+// flavour of JDK package, vendor and features are qualified in version like 21.0.2-tem-jre or 21.0.2-fx-crac,
+// musl is not qualified as it is detected on the host
+type flavour struct {
+	vendor string
+	jre    bool
+	javafx bool
+	crac   bool
+	musl   bool
+}
+
+func hostFlavour() flavour {
+	return flavour{vendor: DefaultVendor, musl: isMusl()}
+}
+
+// parseFlavour parses distribution like tem-jre-fx, parts follow order: vendor, jre, fx, crac
+func parseFlavour(distribution string) (flavour, error) {
+	f := hostFlavour()
+	if distribution == "" {
+		return f, nil
+	}
+	parts := strings.Split(distribution, "-")
+	if _, ok := vendors[parts[0]]; ok {
+		f.vendor = parts[0]
+		parts = parts[1:]
+	}
+	for _, part := range parts {
+		switch part {
+		case "jre":
+			f.jre = true
+		case "fx":
+			f.javafx = true
+		case "crac":
+			f.crac = true
+		default:
+			if _, err := findVendor(part); err != nil {
+				return flavour{}, err
+			}
+			return flavour{}, fmt.Errorf("%v is not a valid distribution of %v, vendor goes first, e.g. %v-jre", distribution, Name, part)
+		}
+	}
+	if qualifier := f.qualifier(); distribution != qualifier && distribution != strings.TrimPrefix(DefaultVendor+"-"+qualifier, "-") {
+		return flavour{}, fmt.Errorf("%v is not a valid distribution of %v, use %v", distribution, Name, qualifier)
+	}
+	return f, nil
+}
+
+// flavourOf composes flavour from options like vendor: tem, package: jre, javafx: true, crac: true
+func flavourOf(options map[string]string) (flavour, error) {
+	f := hostFlavour()
+	for key, value := range options {
+		var err error
+		switch key {
+		case "vendor":
+			if value != "" {
+				_, err = findVendor(value)
+				f.vendor = value
+			}
+		case "package":
+			if value != "" && value != "jdk" && value != "jre" {
+				err = fmt.Errorf("package of %v must be jdk or jre, got: %v", Name, value)
+			}
+			f.jre = value == "jre"
+		case "javafx":
+			f.javafx, err = strconv.ParseBool(value)
+		case "crac":
+			f.crac, err = strconv.ParseBool(value)
+		default:
+			err = fmt.Errorf("unknown option of %v: %v, use vendor, package, javafx or crac", Name, key)
+		}
+		if err != nil {
+			return flavour{}, err
+		}
+	}
+	return f, nil
+}
+
+func (f flavour) qualifier() string {
+	parts := make([]string, 0)
+	if f.vendor != DefaultVendor {
+		parts = append(parts, f.vendor)
+	}
+	if f.jre {
+		parts = append(parts, "jre")
+	}
+	if f.javafx {
+		parts = append(parts, "fx")
+	}
+	if f.crac {
+		parts = append(parts, "crac")
+	}
+	return strings.Join(parts, "-")
+}
+
+func (f flavour) features() []string {
+	var features []string
+	if f.jre {
+		features = append(features, featureJre)
+	}
+	if f.javafx {
+		features = append(features, featureJavafx)
+	}
+	if f.crac {
+		features = append(features, featureCrac)
+	}
+	if f.musl {
+		features = append(features, featureMusl)
+	}
+	return features
+}
+
+func (f flavour) packageType() string {
+	if f.jre {
+		return "jre"
+	}
+	return "jdk"
+}
+
+// isMusl tells whether the host uses musl libc, e.g. Alpine Linux
+func isMusl() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	loaders, err := filepath.Glob("/lib/ld-musl-*.so.1")
+	return err == nil && len(loaders) > 0
+}
+
+func (f flavour) asset(name, version, url string, extraProperties map[string]string) domain.Asset {
+	extraProperties["vendor"] = f.vendor
+	return domain.Asset{
+		Name:            name,
+		Version:         qualify(version, f),
+		Url:             url,
+		Type:            packagingType(url),
+		ExtraProperties: extraProperties,
+		Features:        f.features(),
+	}
+}
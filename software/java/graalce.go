@@ -0,0 +1,104 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const graalcePageSize = 100
+
+type GithubRelease struct {
+	TagName    string        `json:"tag_name"`
+	Draft      bool          `json:"draft"`
+	PreRelease bool          `json:"prerelease"`
+	Assets     []GithubAsset `json:"assets"`
+}
+
+type GithubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadUrl string `json:"browser_download_url"`
+}
+
+func getGraalceAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	var releases []GithubRelease
+	for page := 1; ; page++ {
+		url := GraalceReleasesURL + "?per_page=" + strconv.Itoa(graalcePageSize) + "&page=" + strconv.Itoa(page)
+		var pageOfReleases []GithubRelease
+		err := getJson(ctx, url, &pageOfReleases)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, pageOfReleases...)
+		if len(pageOfReleases) < graalcePageSize {
+			break
+		}
+	}
+	return graalceAssets(releases, f, runtime.GOOS, runtime.GOARCH), nil
+}
+
+// graalceAssets takes community builds released per JDK version, tagged like jdk-21.0.2,
+// older releases tagged like vm-22.3.0 follow GraalVM versioning and are skipped
+func graalceAssets(releases []GithubRelease, f flavour, goOs, goArch string) []domain.Asset {
+	suffix := fmt.Sprintf("_%v-%v_bin.%v", toFoojayOs(goOs), toTemurinArch(goArch), toType(goOs))
+	assets := make([]domain.Asset, 0, len(releases))
+	for _, release := range releases {
+		if release.Draft || release.PreRelease || !strings.HasPrefix(release.TagName, "jdk-") {
+			continue
+		}
+		checksumUrls := make(map[string]string)
+		for _, asset := range release.Assets {
+			checksumUrls[asset.Name] = asset.BrowserDownloadUrl
+		}
+		for _, asset := range release.Assets {
+			if !strings.HasPrefix(asset.Name, "graalvm-community-") || !strings.HasSuffix(asset.Name, suffix) {
+				continue
+			}
+			assets = append(assets, f.asset(asset.Name, strings.TrimPrefix(release.TagName, "jdk-"), asset.BrowserDownloadUrl,
+				map[string]string{"checksumUrl": checksumUrls[asset.Name+".sha256"]}))
+		}
+	}
+	return assets
+}
+
+func verifyGraalceChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	checksumUrl := asset.ExtraProperties["checksumUrl"]
+	if checksumUrl == "" {
+		return fmt.Errorf("no sha256 checksum of %v", asset.Name)
+	}
+	content, err := getText(ctx, checksumUrl)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return fmt.Errorf("no sha256 checksum of %v", asset.Name)
+	}
+	return verification.VerifySha256(fetchedPackage.FilePath, fields[0])
+}
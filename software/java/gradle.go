@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"github.com/spf13/viper"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+const gradleInstallationsProperty = "org.gradle.java.installations.paths"
+
+func gradleUserHome() (string, error) {
+	if gradleUserHome := os.Getenv("GRADLE_USER_HOME"); gradleUserHome != "" {
+		return gradleUserHome, nil
+	}
+	current, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(current.HomeDir, ".gradle"), nil
+}
+
+// updateGradleProperties lists installed JDKs in org.gradle.java.installations.paths, so Gradle toolchains find them,
+// nothing is done if Gradle has never been used
+func updateGradleProperties(installedPackages domain.InstalledPackages) error {
+	gradleHome, err := gradleUserHome()
+	if err != nil {
+		return err
+	}
+	exists, err := file.DirExists(gradleHome)
+	if err != nil || !exists {
+		return err
+	}
+	propertiesPath := filepath.Join(gradleHome, "gradle.properties")
+	console.Info(fmt.Sprintf("Updating Gradle java installations in %s", propertiesPath))
+	content := ""
+	exists, err = file.FileExists(propertiesPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		content, err = file.ReadFile(propertiesPath)
+		if err != nil {
+			return err
+		}
+	}
+	paths, err := gradleInstallationPaths(installedPackages)
+	if err != nil {
+		return err
+	}
+	newContent := mergeGradleProperties(content, paths, managedDir())
+	return file.OverrideFileWithContent(propertiesPath, []string{newContent})
+}
+
+// gradleInstallationPaths returns paths of installed JDKs, JREs are skipped as Gradle cannot compile with them
+func gradleInstallationPaths(installedPackages domain.InstalledPackages) ([]string, error) {
+	var paths []string
+	for _, installedPackage := range installedPackages.Items {
+		f, err := parseFlavour(installedPackage.Version.Distribution())
+		if err != nil {
+			return nil, err
+		}
+		if f.jre {
+			continue
+		}
+		paths = append(paths, installedPackage.Path)
+	}
+	return paths, nil
+}
+
+// managedDir is where JDKs are installed, installations found there and not installed anymore are forgotten
+func managedDir() string {
+	return filepath.Join(viper.GetString(config.SoftwareDirKey), Name)
+}
+
+// mergeGradleProperties sets installation paths, paths added by hand outside managedDir and other properties are kept
+func mergeGradleProperties(content string, paths []string, managedDir string) string {
+	lines := strings.Split(content, "\n")
+	index := -1
+	var values []string
+	for i, line := range lines {
+		key, value, ok := splitProperty(line)
+		if ok && key == gradleInstallationsProperty {
+			index = i
+			for _, existing := range strings.Split(value, ",") {
+				existing = strings.ReplaceAll(strings.TrimSpace(existing), `\\`, `\`)
+				if existing != "" && !isManaged(existing, managedDir) && !contains(paths, existing) {
+					values = append(values, existing)
+				}
+			}
+		}
+	}
+	values = append(values, paths...)
+	for i, value := range values {
+		values[i] = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	property := gradleInstallationsProperty + "=" + strings.Join(values, ",")
+
+	if index >= 0 {
+		if len(values) == 0 {
+			return strings.Join(append(lines[:index], lines[index+1:]...), "\n")
+		}
+		lines[index] = property
+		return strings.Join(lines, "\n")
+	}
+	if len(values) == 0 {
+		return content
+	}
+	if content == "" || strings.HasSuffix(content, "\n") {
+		return content + property + "\n"
+	}
+	return content + "\n" + property + "\n"
+}
+
+func splitProperty(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+		return "", "", false
+	}
+	separator := strings.IndexAny(line, "=:")
+	if separator < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:separator]), strings.TrimSpace(line[separator+1:]), true
+}
+
+func isManaged(path, managedDir string) bool {
+	relative, err := filepath.Rel(managedDir, path)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func TestGradleInstallationPaths(t *testing.T) {
+	version := func(value string) domain.Version {
+		v, _ := domain.NewVersion(value)
+		return v
+	}
+	got, err := gradleInstallationPaths(domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: version("21.0.2-tem"), Path: "/pf/java/tem21"},
+		{Version: version("17.0.10-jre"), Path: "/pf/java/zulu17-jre"},
+		{Version: version("8.0.402"), Path: "/pf/java/zulu8"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/pf/java/tem21", "/pf/java/zulu8"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gradleInstallationPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeGradleProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		paths   []string
+		want    string
+	}{
+		{
+			name:    "property added",
+			content: "org.gradle.daemon=true",
+			paths:   []string{"/pf/java/zulu21", "/pf/java/tem17"},
+			want:    "org.gradle.daemon=true\norg.gradle.java.installations.paths=/pf/java/zulu21,/pf/java/tem17\n",
+		},
+		{
+			name:    "property replaced keeping paths added by hand",
+			content: "# jdks\norg.gradle.java.installations.paths = /opt/jdk11, /pf/java/zulu17\norg.gradle.daemon=true\n",
+			paths:   []string{"/pf/java/zulu21"},
+			want:    "# jdks\norg.gradle.java.installations.paths=/opt/jdk11,/pf/java/zulu21\norg.gradle.daemon=true\n",
+		},
+		{
+			name:    "property removed",
+			content: "org.gradle.java.installations.paths=/pf/java/zulu17\norg.gradle.daemon=true\n",
+			want:    "org.gradle.daemon=true\n",
+		},
+		{
+			name: "nothing to do",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeGradleProperties(tt.content, tt.paths, "/pf/java"); got != tt.want {
+				t.Errorf("mergeGradleProperties() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
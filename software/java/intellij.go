@@ -0,0 +1,303 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software/intellij"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"io"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+const jdkTableComponent = "ProjectJdkTable"
+
+// intellijJdk is home of JDK registered in IntelliJ with its name
+type intellijJdk struct {
+	Name struct {
+		Value string `xml:"value,attr"`
+	} `xml:"name"`
+	HomePath struct {
+		Value string `xml:"value,attr"`
+	} `xml:"homePath"`
+}
+
+// updateIntellijJdkTables registers installed JDKs in each installed IntelliJ version which has been run already
+func updateIntellijJdkTables(installedPackages domain.InstalledPackages) error {
+	configDirs, err := intellij.ConfigDirs()
+	if err != nil {
+		return err
+	}
+	current, err := user.Current()
+	if err != nil {
+		return err
+	}
+	for _, configDir := range configDirs {
+		exists, err := file.DirExists(configDir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		jdkTablePath := filepath.Join(configDir, "options", "jdk.table.xml")
+		console.Info(fmt.Sprintf("Updating IntelliJ JDKs in %s", jdkTablePath))
+		content := ""
+		exists, err = file.FileExists(jdkTablePath)
+		if err != nil {
+			return err
+		}
+		if exists {
+			content, err = file.ReadFile(jdkTablePath)
+			if err != nil {
+				return err
+			}
+		}
+		newContent, err := mergeJdkTable(content, installedPackages, managedDir(), current.HomeDir)
+		if err != nil {
+			return fmt.Errorf("cannot update %s: %w", jdkTablePath, err)
+		}
+		err = file.OverrideFileWithContent(jdkTablePath, []string{newContent})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeJdkTable adds installed JDKs missing in the table and removes JDKs of managedDir which are not installed anymore,
+// other entries are left alone, JREs are not added as IntelliJ cannot compile with them
+func mergeJdkTable(content string, installedPackages domain.InstalledPackages, managedDir, homeDir string) (string, error) {
+	if strings.TrimSpace(content) == "" {
+		content = "<application>\n  <component name=\"" + jdkTableComponent + "\">\n  </component>\n</application>\n"
+	}
+	var installedJdks []domain.InstalledPackage
+	installed := make(map[string]bool)
+	for _, installedPackage := range installedPackages.Items {
+		f, err := parseFlavour(installedPackage.Version.Distribution())
+		if err != nil {
+			return "", err
+		}
+		if f.jre {
+			continue
+		}
+		installedJdks = append(installedJdks, installedPackage)
+		installed[installedPackage.Path] = true
+	}
+
+	type span struct{ start, end int }
+	var removed []span
+	present := make(map[string]bool)
+	names := make(map[string]bool)
+	insertAt := -1
+	inTable := false
+	selfClosing := false
+
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "component" && attr(element, "name") == jdkTableComponent {
+				inTable = true
+			} else if inTable && element.Name.Local == "jdk" {
+				var jdk intellijJdk
+				err = decoder.DecodeElement(&jdk, &element)
+				if err != nil {
+					return "", err
+				}
+				home := strings.ReplaceAll(jdk.HomePath.Value, "$USER_HOME$", homeDir)
+				if isManaged(home, managedDir) && !installed[home] {
+					removed = append(removed, span{start: lineStart(content, offset), end: int(decoder.InputOffset())})
+					continue
+				}
+				present[home] = true
+				names[jdk.Name.Value] = true
+			}
+		case xml.EndElement:
+			if inTable && element.Name.Local == "component" {
+				inTable = false
+				insertAt = lineStart(content, offset)
+				selfClosing = strings.HasSuffix(content[:offset], "/>")
+			}
+		}
+	}
+
+	var jdks []string
+	for _, installedPackage := range installedJdks {
+		if present[installedPackage.Path] {
+			continue
+		}
+		name := installedPackage.Version.Value
+		if names[name] {
+			name += " (svm)"
+		}
+		jdks = append(jdks, intellijJdkEntry(name, installedPackage))
+	}
+	if insertAt < 0 {
+		end := strings.LastIndex(content, "</application>")
+		if end < 0 {
+			return "", errors.New("no </application> found")
+		}
+		component := "  <component name=\"" + jdkTableComponent + "\">\n" + strings.Join(jdks, "") + "  </component>\n"
+		return content[:lineStart(content, end)] + component + content[lineStart(content, end):], nil
+	}
+
+	var result bytes.Buffer
+	previous := 0
+	for _, s := range removed {
+		result.WriteString(content[previous:s.start])
+		previous = s.end
+		// drop rest of the line of removed entry
+		if next := strings.IndexByte(content[previous:], '\n'); next >= 0 && strings.TrimSpace(content[previous:previous+next]) == "" {
+			previous += next + 1
+		}
+	}
+	if selfClosing {
+		// <component name="ProjectJdkTable" /> is opened to take entries
+		result.WriteString(strings.TrimRight(strings.TrimSuffix(content[previous:insertAt], "/>"), " "))
+		result.WriteString(">\n" + strings.Join(jdks, "") + "  </component>")
+	} else {
+		result.WriteString(content[previous:insertAt])
+		result.WriteString(strings.Join(jdks, ""))
+	}
+	result.WriteString(content[insertAt:])
+	return result.String(), nil
+}
+
+func intellijJdkEntry(name string, installedPackage domain.InstalledPackage) string {
+	home := installedPackage.Path
+	version := strings.TrimSuffix(installedPackage.Version.Value, "-"+installedPackage.Version.Distribution())
+	lines := []string{
+		`    <jdk version="2">`,
+		`      <name value="` + escape(name) + `" />`,
+		`      <type value="JavaSDK" />`,
+		`      <version value="` + escape(`java version "`+version+`"`) + `" />`,
+		`      <homePath value="` + escape(home) + `" />`,
+		`      <roots>`,
+		`        <annotationsPath>`,
+		`          <root type="composite">`,
+		`            <root url="jar://$APPLICATION_HOME_DIR$/plugins/java/lib/resources/jdkAnnotations.jar!/" type="simple" />`,
+		`          </root>`,
+		`        </annotationsPath>`,
+	}
+	lines = append(lines, rootsSection("classPath", classRoots(home))...)
+	lines = append(lines, rootsSection("javadocPath", nil)...)
+	lines = append(lines, rootsSection("sourcePath", sourceRoots(home))...)
+	lines = append(lines,
+		`      </roots>`,
+		`      <additional />`,
+		`    </jdk>`,
+		``)
+	return strings.Join(lines, "\n")
+}
+
+func rootsSection(name string, urls []string) []string {
+	if len(urls) == 0 {
+		return []string{`        <` + name + `>`, `          <root type="composite" />`, `        </` + name + `>`}
+	}
+	lines := []string{`        <` + name + `>`, `          <root type="composite">`}
+	for _, url := range urls {
+		lines = append(lines, `            <root url="`+escape(url)+`" type="simple" />`)
+	}
+	return append(lines, `          </root>`, `        </`+name+`>`)
+}
+
+// classRoots returns modules listed in release file of JDK 9+ or jars of JDK 8
+func classRoots(home string) []string {
+	var roots []string
+	for _, module := range jdkModules(home) {
+		roots = append(roots, "jrt://"+filepath.ToSlash(home)+"!/"+module)
+	}
+	if len(roots) > 0 {
+		return roots
+	}
+	jars, _ := filepath.Glob(filepath.Join(home, "jre", "lib", "*.jar"))
+	for _, jar := range jars {
+		roots = append(roots, "jar://"+filepath.ToSlash(jar)+"!/")
+	}
+	return roots
+}
+
+func sourceRoots(home string) []string {
+	var roots []string
+	for _, srcZip := range []string{filepath.Join(home, "lib", "src.zip"), filepath.Join(home, "src.zip")} {
+		exists, err := file.FileExists(srcZip)
+		if err != nil || !exists {
+			continue
+		}
+		modules := jdkModules(home)
+		if len(modules) == 0 {
+			return []string{"jar://" + filepath.ToSlash(srcZip) + "!/"}
+		}
+		for _, module := range modules {
+			roots = append(roots, "jar://"+filepath.ToSlash(srcZip)+"!/"+module)
+		}
+		return roots
+	}
+	return roots
+}
+
+func jdkModules(home string) []string {
+	release, err := file.ReadFile(filepath.Join(home, "release"))
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(release, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "MODULES="); found {
+			return strings.Fields(strings.Trim(value, `"`))
+		}
+	}
+	return nil
+}
+
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func lineStart(content string, offset int) int {
+	start := strings.LastIndex(content[:offset], "\n") + 1
+	if strings.TrimSpace(content[start:offset]) == "" {
+		return start
+	}
+	return offset
+}
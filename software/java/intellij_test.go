@@ -0,0 +1,129 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeJdkTable(t *testing.T) {
+	version := func(value string) domain.Version {
+		v, _ := domain.NewVersion(value)
+		return v
+	}
+	installedPackages := domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: version("21.0.2-tem"), Path: "/pf/java/tem21"},
+		{Version: version("17.0.10"), Path: "/home/u/pf/java/zulu17"},
+		{Version: version("11.0.22-jre"), Path: "/pf/java/zulu11-jre"},
+	}}
+	content := `<application>
+  <component name="ProjectJdkTable">
+    <jdk version="2">
+      <name value="corretto-11" />
+      <homePath value="/opt/jdk11" />
+    </jdk>
+    <jdk version="2">
+      <name value="my zulu 17" />
+      <homePath value="$USER_HOME$/pf/java/zulu17" />
+    </jdk>
+    <jdk version="2">
+      <name value="21.0.1" />
+      <homePath value="/pf/java/zulu21" />
+    </jdk>
+  </component>
+</application>
+`
+	tests := []struct {
+		name       string
+		content    string
+		contains   []string
+		notContain []string
+	}{
+		{
+			name:       "entries added and removed",
+			content:    content,
+			contains:   []string{`<name value="corretto-11" />`, `<name value="my zulu 17" />`, `<name value="21.0.2-tem" />`, `<homePath value="/pf/java/tem21" />`, `<version value="java version &#34;21.0.2&#34;" />`},
+			notContain: []string{`/pf/java/zulu21`, `<name value="17.0.10" />`},
+		},
+		{
+			name:     "table created",
+			content:  "",
+			contains: []string{`<component name="ProjectJdkTable">`, `<name value="21.0.2-tem" />`, `<name value="17.0.10" />`},
+		},
+		{
+			name:       "jre skipped and removed",
+			content:    "<application>\n  <component name=\"ProjectJdkTable\">\n    <jdk version=\"2\">\n      <name value=\"11.0.22-jre\" />\n      <homePath value=\"/pf/java/zulu11-jre\" />\n    </jdk>\n  </component>\n</application>\n",
+			contains:   []string{`<name value="21.0.2-tem" />`},
+			notContain: []string{`11.0.22-jre`, `/pf/java/zulu11-jre`},
+		},
+		{
+			name:     "empty component opened",
+			content:  "<application>\n  <component name=\"ProjectJdkTable\" />\n</application>\n",
+			contains: []string{"<component name=\"ProjectJdkTable\">\n    <jdk version=\"2\">", "    </jdk>\n  </component>\n</application>"},
+		},
+		{
+			name:     "component added",
+			content:  "<application>\n  <component name=\"Other\" />\n</application>\n",
+			contains: []string{`<component name="Other" />`, `<component name="ProjectJdkTable">`, `<name value="21.0.2-tem" />`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeJdkTable(tt.content, installedPackages, "/pf/java", "/home/u")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, expected := range tt.contains {
+				if !strings.Contains(got, expected) {
+					t.Errorf("mergeJdkTable() = %v, does not contain %v", got, expected)
+				}
+			}
+			for _, unexpected := range tt.notContain {
+				if strings.Contains(got, unexpected) {
+					t.Errorf("mergeJdkTable() = %v, contains %v", got, unexpected)
+				}
+			}
+			again, err := mergeJdkTable(got, installedPackages, "/pf/java", "/home/u")
+			if err != nil || again != got {
+				t.Errorf("mergeJdkTable() is not idempotent: %v, %v", again, err)
+			}
+		})
+	}
+}
+
+func TestClassRoots(t *testing.T) {
+	home := t.TempDir()
+	err := os.WriteFile(filepath.Join(home, "release"), []byte("JAVA_VERSION=\"21.0.2\"\nMODULES=\"java.base java.sql\"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"jrt://" + filepath.ToSlash(home) + "!/java.base", "jrt://" + filepath.ToSlash(home) + "!/java.sql"}
+	if got := classRoots(home); !reflect.DeepEqual(got, want) {
+		t.Errorf("classRoots() = %v, want %v", got, want)
+	}
+}
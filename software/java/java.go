@@ -22,58 +22,95 @@
 package java
 
 import (
+	"context"
+	"github.com/pkk82/soft-ver-man/config"
 	"github.com/pkk82/soft-ver-man/domain"
-	"github.com/pkk82/soft-ver-man/util/verification"
+	"github.com/pkk82/soft-ver-man/util/console"
 )
 
+type plugin struct {
+	domain.PluginInfo
+}
+
 func init() {
-	var plugin = domain.Plugin{
-		Name:               Name,
-		EnvNamePrefix:      EnvPrefix,
-		EnvNameSuffix:      EnvSuffix,
-		GetAvailableAssets: getAvailableAssets,
-		VerifyChecksum:     verifyChecksum,
-		PostUninstall: func(version domain.Version) error {
-			return nil
+	domain.Register(plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   Name,
+			EnvNamePrefix:          EnvPrefix,
+			EnvNameSuffix:          EnvSuffix,
+			ExtractStrategy:        domain.UseCompressedDirOrArchiveName,
+			ExecutableRelativePath: "bin",
+			VersionGranularity:     domain.VersionGranularityMajor,
 		},
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return nil
-		},
-		CalculateDownloadedFileName: calculateDownloadFileName,
-		ExtractStrategy:             domain.UseCompressedDirOrArchiveName,
-		ExecutableRelativePath:      "bin",
-		VersionGranularity:          domain.VersionGranularityMajor,
-	}
-	domain.Register(plugin)
+	})
 }
 
-func calculateDownloadFileName(asset domain.Asset) string {
-	return asset.Name
+func (plugin) PostInstall(domain.InstalledPackage) error {
+	return registerInstallations()
 }
 
-func getAvailableAssets() ([]domain.Asset, error) {
-	packages := getSupportedPackages()
-	assets := make([]domain.Asset, len(packages))
-	for i, p := range packages {
-		assets[i] = domain.Asset{
-			Name:            p.Name,
-			Version:         p.version(),
-			Url:             p.DownloadUrl,
-			Type:            p.packagingType(),
-			ExtraProperties: map[string]string{"packageId": p.Id},
+func (plugin) PostUninstall(domain.Version) error {
+	return registerInstallations()
+}
+
+// registerInstallations makes installed JDKs known to Maven, Gradle, VS Code and IntelliJ,
+// e.g. broken settings of one tool are reported and the others are still updated
+func registerInstallations() error {
+	installedPackages, err := config.LoadInstalledPackages(Name)
+	if err != nil {
+		return err
+	}
+	for _, register := range []func(domain.InstalledPackages) error{updateToolchains, updateGradleProperties, updateVsCodeSettings, updateIntellijJdkTables} {
+		err = register(installedPackages)
+		if err != nil {
+			console.Warn(err)
 		}
 	}
-	return assets, nil
+	return nil
+}
+
+func (plugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	return vendors[DefaultVendor].listAssets(ctx, hostFlavour())
+}
+
+func (plugin) DefaultDistribution() string {
+	return DefaultVendor
+}
+
+func (plugin) Distribution(options map[string]string) (string, error) {
+	f, err := flavourOf(options)
+	if err != nil {
+		return "", err
+	}
+	return f.qualifier(), nil
 }
 
-func verifyChecksum(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
-	extendedPackage, err := getExtendedPackage(asset.ExtraProperties["packageId"])
+func (plugin) SplitDistribution(distribution string) (string, []string, error) {
+	f, err := parseFlavour(distribution)
 	if err != nil {
-		return err
+		return "", nil, err
+	}
+	// musl is not qualified, the host does not tell what the package was built for
+	f.musl = false
+	return f.vendor, f.features(), nil
+}
+
+func (plugin) GetDistributionAssets(ctx context.Context, distribution string) ([]domain.Asset, error) {
+	f, err := parseFlavour(distribution)
+	if err != nil {
+		return nil, err
 	}
-	err = verification.VerifySha256(fetchedPackage.FilePath, extendedPackage.Sha256)
+	v, err := findVendor(f.vendor)
+	if err != nil {
+		return nil, err
+	}
+	return v.listAssets(ctx, f)
+}
+
+func (plugin) VerifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	v, err := findVendor(asset.ExtraProperties["vendor"])
 	if err != nil {
 		return err
 	}
-	return nil
+	return v.verifyChecksum(ctx, asset, fetchedPackage)
 }
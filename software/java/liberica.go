@@ -0,0 +1,113 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"runtime"
+	"strconv"
+)
+
+type LibericaRelease struct {
+	FeatureVersion int    `json:"featureVersion"`
+	InterimVersion int    `json:"interimVersion"`
+	UpdateVersion  int    `json:"updateVersion"`
+	PatchVersion   int    `json:"patchVersion"`
+	DownloadUrl    string `json:"downloadUrl"`
+	Filename       string `json:"filename"`
+	Sha1           string `json:"sha1"`
+	GA             bool   `json:"GA"`
+}
+
+func (r LibericaRelease) version() string {
+	version := fmt.Sprintf("%d.%d.%d", r.FeatureVersion, r.InterimVersion, r.UpdateVersion)
+	if r.PatchVersion > 0 {
+		version += "." + strconv.Itoa(r.PatchVersion)
+	}
+	return version
+}
+
+func getLibericaAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	bundleType, err := libericaBundleType(f)
+	if err != nil {
+		return nil, err
+	}
+	arch, bitness := toLibericaArch(runtime.GOARCH)
+	url := LibericaAPIURL + "?bundle-type=" + bundleType +
+		"&os=" + toOs(runtime.GOOS, f.musl) +
+		"&arch=" + arch +
+		"&bitness=" + bitness +
+		"&package-type=" + toType(runtime.GOOS)
+	var releases []LibericaRelease
+	err = getJson(ctx, url, &releases)
+	if err != nil {
+		return nil, err
+	}
+	return libericaAssets(releases, f), nil
+}
+
+// libericaBundleType maps flavour to bundle like jdk, jre-full (with JavaFX) or jdk-crac
+func libericaBundleType(f flavour) (string, error) {
+	if f.javafx && f.crac {
+		return "", &domain.UnsupportedError{Name: "BellSoft Liberica", Operation: "javafx package with crac"}
+	}
+	if f.javafx {
+		return f.packageType() + "-full", nil
+	}
+	if f.crac {
+		return f.packageType() + "-crac", nil
+	}
+	return f.packageType(), nil
+}
+
+func libericaAssets(releases []LibericaRelease, f flavour) []domain.Asset {
+	assets := make([]domain.Asset, 0, len(releases))
+	seen := make(map[string]bool)
+	for _, release := range releases {
+		version := release.version()
+		if !release.GA || seen[version] {
+			continue
+		}
+		seen[version] = true
+		assets = append(assets, f.asset(release.Filename, version, release.DownloadUrl, map[string]string{"sha1": release.Sha1}))
+	}
+	return assets
+}
+
+// verifyLibericaChecksum uses sha1, the only checksum published by BellSoft API
+func verifyLibericaChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	return verification.VerifySha1(fetchedPackage.FilePath, asset.ExtraProperties["sha1"])
+}
+
+func toLibericaArch(goArch string) (string, string) {
+	switch goArch {
+	case "arm64":
+		return "arm", "64"
+	case "386":
+		return "x86", "32"
+	}
+	return "x86", "64"
+}
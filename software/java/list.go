@@ -23,22 +23,41 @@
 package java
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"io"
-	"net/http"
 	"runtime"
 	"strconv"
 	"strings"
 )
 
-func getSupportedPackages() []Package {
+func getZuluAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	packages, err := getSupportedPackages(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	assets := make([]domain.Asset, len(packages))
+	for i, p := range packages {
+		assets[i] = f.asset(p.Name, p.version(), p.DownloadUrl, map[string]string{"packageId": p.Id})
+		if p.Latest {
+			assets[i].Channels = []string{domain.ChannelLatest}
+		}
+	}
+	return assets, nil
+}
+
+func getSupportedPackages(ctx context.Context, f flavour) ([]Package, error) {
 	var allPackages []Package
 
 	pageNo := 1
 	for {
-		pagination, packages := getPageOfSupportedPackages(pageNo)
+		pagination, packages, err := getPageOfSupportedPackages(ctx, pageNo, f)
+		if err != nil {
+			return nil, err
+		}
 		for _, pkg := range packages {
 			allPackages = append(allPackages, pkg)
 		}
@@ -47,7 +66,7 @@ func getSupportedPackages() []Package {
 		}
 		pageNo = pagination.NextPage
 	}
-	return allPackages
+	return allPackages, nil
 }
 
 type Package struct {
@@ -63,13 +82,6 @@ func (p Package) version() string {
 	return strings.Join(intSliceToStringSlice(p.JavaVersion), ".")
 }
 
-func (p Package) packagingType() domain.Type {
-	if strings.HasSuffix(p.DownloadUrl, ".zip") {
-		return domain.ZIP
-	}
-	return domain.TAR_GZ
-}
-
 type Pagination struct {
 	Total      int `json:"total"`
 	TotalPages int `json:"total_pages"`
@@ -79,45 +91,48 @@ type Pagination struct {
 	NextPage   int `json:"next_page"`
 }
 
-func getPageOfSupportedPackages(pageNo int) (Pagination, []Package) {
+func getPageOfSupportedPackages(ctx context.Context, pageNo int, f flavour) (Pagination, []Package, error) {
 	url := PackagesAPIURL + "?page=" + strconv.Itoa(pageNo) +
 		"&page_size=" + strconv.Itoa(PageSize) +
-		"&javafx_bundled=false" +
-		"&crac_supported=false" +
+		"&javafx_bundled=" + strconv.FormatBool(f.javafx) +
+		"&crac_supported=" + strconv.FormatBool(f.crac) +
 		"&release_status=ga" +
-		"&java_package_type=jdk" +
-		"&os=" + toOs(runtime.GOOS) +
+		"&java_package_type=" + f.packageType() +
+		"&os=" + toOs(runtime.GOOS, f.musl) +
 		"&arch=" + toArch(runtime.GOARCH) +
 		"&archive_type=" + toType(runtime.GOOS)
-	resp, err := http.Get(url)
-
-	var pagination Pagination
-	err = json.Unmarshal([]byte(resp.Header.Get("X-Pagination")), &pagination)
-	if err != nil {
-		console.Fatal(err)
-	}
-
+	resp, err := web.Get(ctx, url)
 	if err != nil {
-		console.Fatal(err)
+		return Pagination{}, nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			console.Fatal(err)
+			console.Error(err)
 		}
 	}(resp.Body)
+
+	var pagination Pagination
+	err = json.Unmarshal([]byte(resp.Header.Get("X-Pagination")), &pagination)
+	if err != nil {
+		return Pagination{}, nil, err
+	}
+
 	var packages []Package
 	err = json.NewDecoder(resp.Body).Decode(&packages)
 	if err != nil {
-		console.Fatal(err)
+		return Pagination{}, nil, err
 	}
-	return pagination, packages
+	return pagination, packages, nil
 }
 
-func toOs(goOpSystem string) string {
+func toOs(goOpSystem string, musl bool) string {
 	if goOpSystem == "darwin" {
 		return "macos"
 	}
+	if goOpSystem == "linux" && musl {
+		return "linux-musl"
+	}
 	if goOpSystem == "linux" {
 		return "linux-glibc"
 	}
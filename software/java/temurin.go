@@ -0,0 +1,135 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/web"
+	"net/http"
+	"runtime"
+	"strconv"
+)
+
+const temurinPageSize = 20
+
+type TemurinRelease struct {
+	ReleaseName string          `json:"release_name"`
+	Binaries    []TemurinBinary `json:"binaries"`
+	VersionData TemurinVersion  `json:"version_data"`
+}
+
+type TemurinBinary struct {
+	Package TemurinPackage `json:"package"`
+}
+
+type TemurinPackage struct {
+	Name     string `json:"name"`
+	Link     string `json:"link"`
+	Checksum string `json:"checksum"`
+}
+
+type TemurinVersion struct {
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+	Security int `json:"security"`
+	Patch    int `json:"patch"`
+}
+
+func (v TemurinVersion) version() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Security)
+	if v.Patch > 0 {
+		version += "." + strconv.Itoa(v.Patch)
+	}
+	return version
+}
+
+func getTemurinAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	var releases []TemurinRelease
+	for page := 0; ; page++ {
+		url := TemurinAPIURL + "?page=" + strconv.Itoa(page) +
+			"&page_size=" + strconv.Itoa(temurinPageSize) +
+			"&release_type=ga" +
+			"&image_type=" + f.packageType() +
+			"&jvm_impl=hotspot" +
+			"&heap_size=normal" +
+			"&vendor=eclipse" +
+			"&sort_order=DESC" +
+			"&os=" + toTemurinOs(runtime.GOOS, f.musl) +
+			"&architecture=" + toTemurinArch(runtime.GOARCH)
+		var pageOfReleases []TemurinRelease
+		err := getJson(ctx, url, &pageOfReleases)
+		var httpError *web.HTTPError
+		if errors.As(err, &httpError) && httpError.StatusCode == http.StatusNotFound {
+			// API responds with not found past the last page
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, pageOfReleases...)
+		if len(pageOfReleases) < temurinPageSize {
+			break
+		}
+	}
+	return temurinAssets(releases, f), nil
+}
+
+func temurinAssets(releases []TemurinRelease, f flavour) []domain.Asset {
+	assets := make([]domain.Asset, 0, len(releases))
+	seen := make(map[string]bool)
+	for _, release := range releases {
+		version := release.VersionData.version()
+		if len(release.Binaries) == 0 || seen[version] {
+			continue
+		}
+		seen[version] = true
+		binary := release.Binaries[0]
+		assets = append(assets, f.asset(binary.Package.Name, version, binary.Package.Link, map[string]string{"sha256": binary.Package.Checksum}))
+	}
+	return assets
+}
+
+func toTemurinOs(goOpSystem string, musl bool) string {
+	if goOpSystem == "darwin" {
+		return "mac"
+	}
+	if goOpSystem == "linux" && musl {
+		return "alpine-linux"
+	}
+	return goOpSystem
+}
+
+func toTemurinArch(goArch string) string {
+	switch goArch {
+	case "amd64":
+		return "x64"
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "x32"
+	}
+	return goArch
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+const toolchainsSectionBegin = "<!-- soft-ver-man toolchains -->"
+const toolchainsSectionEnd = "<!-- soft-ver-man toolchains end -->"
+const toolchainsEnd = "</toolchains>"
+
+func ToolchainsPath() (string, error) {
+	current, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(current.HomeDir, ".m2", "toolchains.xml"), nil
+}
+
+// Toolchains returns content of toolchains.xml with managed section listing installed JDKs,
+// entries outside the section are kept as they are
+func Toolchains() (string, error) {
+	installedPackages, err := config.LoadInstalledPackages(Name)
+	if err != nil {
+		return "", err
+	}
+	return toolchains(installedPackages)
+}
+
+func SyncToolchains() error {
+	installedPackages, err := config.LoadInstalledPackages(Name)
+	if err != nil {
+		return err
+	}
+	return syncToolchains(installedPackages)
+}
+
+func toolchains(installedPackages domain.InstalledPackages) (string, error) {
+	toolchainsPath, err := ToolchainsPath()
+	if err != nil {
+		return "", err
+	}
+	content := ""
+	exists, err := file.FileExists(toolchainsPath)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		content, err = file.ReadFile(toolchainsPath)
+		if err != nil {
+			return "", err
+		}
+	}
+	section, err := toolchainsSection(installedPackages)
+	if err != nil {
+		return "", err
+	}
+	return mergeToolchains(content, section)
+}
+
+func syncToolchains(installedPackages domain.InstalledPackages) error {
+	toolchainsPath, err := ToolchainsPath()
+	if err != nil {
+		return err
+	}
+	content, err := toolchains(installedPackages)
+	if err != nil {
+		return err
+	}
+	return file.OverrideFileWithContent(toolchainsPath, []string{content})
+}
+
+func updateToolchains(installedPackages domain.InstalledPackages) error {
+	toolchainsPath, err := ToolchainsPath()
+	if err != nil {
+		return err
+	}
+	console.Info(fmt.Sprintf("Updating Maven toolchains in %s", toolchainsPath))
+	return syncToolchains(installedPackages)
+}
+
+// toolchainsSection lists JDKs only as JREs cannot compile, JDKs bundled with JavaFX or CRaC are told apart by features
+func toolchainsSection(installedPackages domain.InstalledPackages) (string, error) {
+	lines := []string{"  " + toolchainsSectionBegin}
+	for _, installedPackage := range installedPackages.Items {
+		version := installedPackage.Version
+		f, err := parseFlavour(version.Distribution())
+		if err != nil {
+			return "", err
+		}
+		if f.jre {
+			continue
+		}
+		f.musl = false
+		lines = append(lines,
+			"  <toolchain>",
+			"    <type>jdk</type>",
+			"    <provides>",
+			"      <version>"+escape(strings.TrimSuffix(version.Value, "-"+version.Distribution()))+"</version>",
+			"      <vendor>"+escape(f.vendor)+"</vendor>")
+		if features := f.features(); len(features) > 0 {
+			lines = append(lines, "      <features>"+escape(strings.Join(features, ","))+"</features>")
+		}
+		lines = append(lines,
+			"    </provides>",
+			"    <configuration>",
+			"      <jdkHome>"+escape(installedPackage.Path)+"</jdkHome>",
+			"    </configuration>",
+			"  </toolchain>")
+	}
+	lines = append(lines, "  "+toolchainsSectionEnd)
+	return strings.Join(lines, "\n"), nil
+}
+
+// mergeToolchains replaces managed section of content, the section is added before closing tag if it does not exist yet
+func mergeToolchains(content, section string) (string, error) {
+	if strings.TrimSpace(content) == "" {
+		return strings.Join([]string{
+			`<?xml version="1.0" encoding="UTF-8"?>`,
+			`<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0"`,
+			`            xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"`,
+			`            xsi:schemaLocation="http://maven.apache.org/TOOLCHAINS/1.1.0 https://maven.apache.org/xsd/toolchains-1.1.0.xsd">`,
+			section,
+			toolchainsEnd,
+			"",
+		}, "\n"), nil
+	}
+	beginIndex := strings.Index(content, toolchainsSectionBegin)
+	if beginIndex >= 0 {
+		endIndex := strings.Index(content[beginIndex:], toolchainsSectionEnd)
+		if endIndex >= 0 {
+			endIndex += beginIndex + len(toolchainsSectionEnd)
+			// section carries its own indentation
+			lineStart := strings.LastIndex(content[:beginIndex], "\n") + 1
+			if strings.TrimSpace(content[lineStart:beginIndex]) == "" {
+				beginIndex = lineStart
+			}
+			return content[:beginIndex] + section + content[endIndex:], nil
+		}
+	}
+	endIndex := strings.LastIndex(content, toolchainsEnd)
+	if endIndex < 0 {
+		return "", fmt.Errorf("no %s found in toolchains.xml", toolchainsEnd)
+	}
+	return content[:endIndex] + section + "\n" + content[endIndex:], nil
+}
+
+func escape(value string) string {
+	var builder strings.Builder
+	_ = xml.EscapeText(&builder, []byte(value))
+	return builder.String()
+}
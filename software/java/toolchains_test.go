@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"strings"
+	"testing"
+)
+
+func TestToolchainsSection(t *testing.T) {
+	section, err := toolchainsSection(domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: domain.Ver("21.0.2-tem", t), Path: "/opt/java/jdk-21&tem"},
+		{Version: domain.Ver("21.0.2-tem-jre", t), Path: "/opt/java/jre-21-tem"},
+		{Version: domain.Ver("17.0.10", t), Path: "/opt/java/zulu17"},
+		{Version: domain.Ver("17.0.10-fx-crac", t), Path: "/opt/java/zulu17-fx-crac"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"<version>21.0.2</version>",
+		"<vendor>tem</vendor>",
+		"<jdkHome>/opt/java/jdk-21&amp;tem</jdkHome>",
+		"<version>17.0.10</version>",
+		"<vendor>zulu</vendor>",
+		"<jdkHome>/opt/java/zulu17</jdkHome>",
+		"<features>javafx,crac</features>",
+		"<jdkHome>/opt/java/zulu17-fx-crac</jdkHome>",
+	} {
+		if !strings.Contains(section, expected) {
+			t.Errorf("section %v does not contain %v", section, expected)
+		}
+	}
+	if strings.Contains(section, "jre-21-tem") || strings.Count(section, "<features>") != 1 {
+		t.Errorf("section %v should list JDKs only, with features of JavaFX and CRaC one", section)
+	}
+}
+
+func TestMergeToolchains(t *testing.T) {
+	section := "  " + toolchainsSectionBegin + "\n  <toolchain/>\n  " + toolchainsSectionEnd
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "section added before closing tag",
+			content: "<toolchains>\n  <toolchain>hand</toolchain>\n</toolchains>\n",
+			want:    "<toolchains>\n  <toolchain>hand</toolchain>\n" + section + "\n</toolchains>\n",
+		},
+		{
+			name:    "section replaced",
+			content: "<toolchains>\n  " + toolchainsSectionBegin + "\n  <toolchain>old</toolchain>\n  " + toolchainsSectionEnd + "\n  <toolchain>hand</toolchain>\n</toolchains>\n",
+			want:    "<toolchains>\n" + section + "\n  <toolchain>hand</toolchain>\n</toolchains>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeToolchains(tt.content, section)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("mergeToolchains() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	got, err := mergeToolchains("", section)
+	if err != nil || !strings.Contains(got, section+"\n"+toolchainsEnd) {
+		t.Errorf("mergeToolchains() of empty file = %q, %v", got, err)
+	}
+	if _, err := mergeToolchains("<settings/>", section); err == nil {
+		t.Errorf("mergeToolchains() expected error for file without toolchains")
+	}
+}
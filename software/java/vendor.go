@@ -0,0 +1,102 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"github.com/pkk82/soft-ver-man/domain"
+	"sort"
+	"strings"
+)
+
+const DefaultVendor = "zulu"
+
+// vendor builds JDK, versions of vendors other than the default one are qualified with vendor id, e.g. 21.0.2-tem
+type vendor struct {
+	id             string
+	name           string
+	features       []string
+	list           func(ctx context.Context, f flavour) ([]domain.Asset, error)
+	verifyChecksum func(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error
+}
+
+var vendors = map[string]vendor{
+	DefaultVendor: {id: DefaultVendor, name: "Azul Zulu", features: []string{featureJre, featureJavafx, featureCrac, featureMusl},
+		list: getZuluAssets, verifyChecksum: verifyZuluChecksum},
+	"tem": {id: "tem", name: "Eclipse Temurin", features: []string{featureJre, featureMusl},
+		list: getTemurinAssets, verifyChecksum: verifySha256Property},
+	"corretto": {id: "corretto", name: "Amazon Corretto", features: []string{featureJre, featureMusl},
+		list: getCorrettoAssets, verifyChecksum: verifyCorrettoChecksum},
+	"graalce": {id: "graalce", name: "GraalVM CE",
+		list: getGraalceAssets, verifyChecksum: verifyGraalceChecksum},
+	"liberica": {id: "liberica", name: "BellSoft Liberica", features: []string{featureJre, featureJavafx, featureCrac, featureMusl},
+		list: getLibericaAssets, verifyChecksum: verifyLibericaChecksum},
+}
+
+func findVendor(id string) (vendor, error) {
+	if id == "" {
+		id = DefaultVendor
+	}
+	v, ok := vendors[id]
+	if !ok {
+		ids := make([]string, 0, len(vendors))
+		for vendorId := range vendors {
+			ids = append(ids, vendorId)
+		}
+		sort.Strings(ids)
+		return vendor{}, &domain.UnsupportedError{Name: Name, Operation: "vendor " + id + " (use one of " + strings.Join(ids, ", ") + ")"}
+	}
+	return v, nil
+}
+
+func (v vendor) listAssets(ctx context.Context, f flavour) ([]domain.Asset, error) {
+	for _, feature := range f.features() {
+		if !v.supports(feature) {
+			return nil, &domain.UnsupportedError{Name: v.name, Operation: feature + " package"}
+		}
+	}
+	return v.list(ctx, f)
+}
+
+func (v vendor) supports(feature string) bool {
+	for _, supported := range v.features {
+		if supported == feature {
+			return true
+		}
+	}
+	return false
+}
+
+func qualify(version string, f flavour) string {
+	if qualifier := f.qualifier(); qualifier != "" {
+		return version + "-" + qualifier
+	}
+	return version
+}
+
+func packagingType(url string) domain.Type {
+	if strings.HasSuffix(url, ".zip") {
+		return domain.ZIP
+	}
+	return domain.TAR_GZ
+}
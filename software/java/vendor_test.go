@@ -0,0 +1,213 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"context"
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func TestTemurinAssets(t *testing.T) {
+	releases := []TemurinRelease{
+		{ReleaseName: "jdk-21.0.2+13", VersionData: TemurinVersion{Major: 21, Minor: 0, Security: 2}, Binaries: []TemurinBinary{
+			{Package: TemurinPackage{Name: "OpenJDK21U-jdk_x64_linux_hotspot_21.0.2_13.tar.gz", Link: "https://example.com/OpenJDK21U-jdk_x64_linux_hotspot_21.0.2_13.tar.gz", Checksum: "abc"}},
+		}},
+		{ReleaseName: "jdk-11.0.21+9.1", VersionData: TemurinVersion{Major: 11, Minor: 0, Security: 21, Patch: 1}, Binaries: []TemurinBinary{
+			{Package: TemurinPackage{Name: "OpenJDK11U-jdk_x64_windows_hotspot_11.0.21_9.zip", Link: "https://example.com/OpenJDK11U-jdk_x64_windows_hotspot_11.0.21_9.zip", Checksum: "def"}},
+		}},
+		{ReleaseName: "jdk-11.0.21+9", VersionData: TemurinVersion{Major: 11, Minor: 0, Security: 21}},
+	}
+	want := []domain.Asset{
+		{Name: "OpenJDK21U-jdk_x64_linux_hotspot_21.0.2_13.tar.gz", Version: "21.0.2-tem-jre", Url: "https://example.com/OpenJDK21U-jdk_x64_linux_hotspot_21.0.2_13.tar.gz", Type: domain.TAR_GZ,
+			ExtraProperties: map[string]string{"vendor": "tem", "sha256": "abc"}, Features: []string{"jre"}},
+		{Name: "OpenJDK11U-jdk_x64_windows_hotspot_11.0.21_9.zip", Version: "11.0.21.1-tem-jre", Url: "https://example.com/OpenJDK11U-jdk_x64_windows_hotspot_11.0.21_9.zip", Type: domain.ZIP,
+			ExtraProperties: map[string]string{"vendor": "tem", "sha256": "def"}, Features: []string{"jre"}},
+	}
+	if got := temurinAssets(releases, flavour{vendor: "tem", jre: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("temurinAssets() = %v, want %v", got, want)
+	}
+}
+
+func TestCorrettoAssets(t *testing.T) {
+	packages := []FoojayPackage{
+		{Id: "1", Filename: "amazon-corretto-21.0.2.13.1-linux-x64.tar.gz", JavaVersion: "21.0.2+13", DirectDownloadUri: "https://example.com/amazon-corretto-21.0.2.13.1-linux-x64.tar.gz"},
+		{Id: "2", Filename: "amazon-corretto-21.0.2.13.0-linux-x64.tar.gz", JavaVersion: "21.0.2+13", DirectDownloadUri: "https://example.com/amazon-corretto-21.0.2.13.0-linux-x64.tar.gz"},
+	}
+	want := []domain.Asset{
+		{Name: "amazon-corretto-21.0.2.13.1-linux-x64.tar.gz", Version: "21.0.2-corretto", Url: "https://example.com/amazon-corretto-21.0.2.13.1-linux-x64.tar.gz", Type: domain.TAR_GZ,
+			ExtraProperties: map[string]string{"vendor": "corretto", "packageId": "1"}},
+	}
+	if got := correttoAssets(packages, flavour{vendor: "corretto"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("correttoAssets() = %v, want %v", got, want)
+	}
+}
+
+func TestGraalceAssets(t *testing.T) {
+	releases := []GithubRelease{
+		{TagName: "jdk-21.0.2", Assets: []GithubAsset{
+			{Name: "graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz", BrowserDownloadUrl: "https://example.com/graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz"},
+			{Name: "graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz.sha256", BrowserDownloadUrl: "https://example.com/graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz.sha256"},
+			{Name: "graalvm-community-jdk-21.0.2_linux-aarch64_bin.tar.gz", BrowserDownloadUrl: "https://example.com/graalvm-community-jdk-21.0.2_linux-aarch64_bin.tar.gz"},
+			{Name: "graalvm-community-jdk-21.0.2_windows-x64_bin.zip", BrowserDownloadUrl: "https://example.com/graalvm-community-jdk-21.0.2_windows-x64_bin.zip"},
+		}},
+		{TagName: "jdk-23.0.0-ea.01", PreRelease: true, Assets: []GithubAsset{
+			{Name: "graalvm-community-jdk-23.0.0-ea.01_linux-x64_bin.tar.gz", BrowserDownloadUrl: "https://example.com/graalvm-community-jdk-23.0.0-ea.01_linux-x64_bin.tar.gz"},
+		}},
+		{TagName: "vm-22.3.0", Assets: []GithubAsset{
+			{Name: "graalvm-ce-java17-linux-amd64-22.3.0.tar.gz", BrowserDownloadUrl: "https://example.com/graalvm-ce-java17-linux-amd64-22.3.0.tar.gz"},
+		}},
+	}
+	want := []domain.Asset{
+		{Name: "graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz", Version: "21.0.2-graalce", Url: "https://example.com/graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz", Type: domain.TAR_GZ,
+			ExtraProperties: map[string]string{"vendor": "graalce", "checksumUrl": "https://example.com/graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz.sha256"}},
+	}
+	if got := graalceAssets(releases, flavour{vendor: "graalce"}, "linux", "amd64"); !reflect.DeepEqual(got, want) {
+		t.Errorf("graalceAssets() = %v, want %v", got, want)
+	}
+}
+
+func TestLibericaAssets(t *testing.T) {
+	releases := []LibericaRelease{
+		{FeatureVersion: 21, UpdateVersion: 2, DownloadUrl: "https://example.com/bellsoft-jdk21.0.2+14-linux-amd64.tar.gz", Filename: "bellsoft-jdk21.0.2+14-linux-amd64.tar.gz", Sha1: "abc", GA: true},
+		{FeatureVersion: 8, UpdateVersion: 402, PatchVersion: 1, DownloadUrl: "https://example.com/bellsoft-jdk8u402+7-linux-amd64.tar.gz", Filename: "bellsoft-jdk8u402+7-linux-amd64.tar.gz", Sha1: "def", GA: true},
+		{FeatureVersion: 23, DownloadUrl: "https://example.com/bellsoft-jdk23+15-linux-amd64.tar.gz", Filename: "bellsoft-jdk23+15-linux-amd64.tar.gz", Sha1: "ghi", GA: false},
+	}
+	want := []domain.Asset{
+		{Name: "bellsoft-jdk21.0.2+14-linux-amd64.tar.gz", Version: "21.0.2-liberica", Url: "https://example.com/bellsoft-jdk21.0.2+14-linux-amd64.tar.gz", Type: domain.TAR_GZ,
+			ExtraProperties: map[string]string{"vendor": "liberica", "sha1": "abc"}, Features: []string{"musl"}},
+		{Name: "bellsoft-jdk8u402+7-linux-amd64.tar.gz", Version: "8.0.402.1-liberica", Url: "https://example.com/bellsoft-jdk8u402+7-linux-amd64.tar.gz", Type: domain.TAR_GZ,
+			ExtraProperties: map[string]string{"vendor": "liberica", "sha1": "def"}, Features: []string{"musl"}},
+	}
+	if got := libericaAssets(releases, flavour{vendor: "liberica", musl: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("libericaAssets() = %v, want %v", got, want)
+	}
+}
+
+func TestFindVendor(t *testing.T) {
+	for _, id := range []string{"", "zulu", "tem", "corretto", "graalce", "liberica"} {
+		if _, err := findVendor(id); err != nil {
+			t.Errorf("findVendor(%v) error = %v", id, err)
+		}
+	}
+	if _, err := findVendor("oracle"); err == nil {
+		t.Errorf("findVendor(oracle) expected error")
+	}
+}
+
+func TestParseFlavour(t *testing.T) {
+	tests := []struct {
+		distribution string
+		want         string
+		features     []string
+		wantErr      bool
+	}{
+		{distribution: "", want: ""},
+		{distribution: "tem", want: "tem"},
+		{distribution: "jre", want: "jre", features: []string{"jre"}},
+		{distribution: "zulu-jre", want: "jre", features: []string{"jre"}},
+		{distribution: "tem-jre", want: "tem-jre", features: []string{"jre"}},
+		{distribution: "fx-crac", want: "fx-crac", features: []string{"javafx", "crac"}},
+		{distribution: "jre-fx-crac", want: "jre-fx-crac", features: []string{"jre", "javafx", "crac"}},
+		{distribution: "crac-fx", wantErr: true},
+		{distribution: "oracle", wantErr: true},
+		{distribution: "jre-tem", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.distribution, func(t *testing.T) {
+			got, err := parseFlavour(tt.distribution)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFlavour() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got.musl = false
+			if got.qualifier() != tt.want || !reflect.DeepEqual(got.features(), tt.features) {
+				t.Errorf("parseFlavour() = %v %v, want %v %v", got.qualifier(), got.features(), tt.want, tt.features)
+			}
+		})
+	}
+}
+
+func TestSplitDistribution(t *testing.T) {
+	tests := []struct {
+		distribution string
+		vendor       string
+		features     []string
+	}{
+		{distribution: "", vendor: "zulu"},
+		{distribution: "jre", vendor: "zulu", features: []string{"jre"}},
+		{distribution: "tem-jre", vendor: "tem", features: []string{"jre"}},
+		{distribution: "liberica-fx-crac", vendor: "liberica", features: []string{"javafx", "crac"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.distribution, func(t *testing.T) {
+			vendor, features, err := plugin{}.SplitDistribution(tt.distribution)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if vendor != tt.vendor || !reflect.DeepEqual(features, tt.features) {
+				t.Errorf("SplitDistribution() = %v %v, want %v %v", vendor, features, tt.vendor, tt.features)
+			}
+		})
+	}
+}
+
+func TestFlavourOf(t *testing.T) {
+	tests := []struct {
+		options map[string]string
+		want    string
+		wantErr bool
+	}{
+		{options: map[string]string{}, want: ""},
+		{options: map[string]string{"vendor": "zulu", "package": "jdk"}, want: ""},
+		{options: map[string]string{"vendor": "tem", "package": "jre"}, want: "tem-jre"},
+		{options: map[string]string{"javafx": "true", "crac": "true"}, want: "fx-crac"},
+		{options: map[string]string{"vendor": "oracle"}, wantErr: true},
+		{options: map[string]string{"package": "jmods"}, wantErr: true},
+		{options: map[string]string{"javafx": "maybe"}, wantErr: true},
+		{options: map[string]string{"libc": "musl"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := flavourOf(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("flavourOf() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.qualifier() != tt.want {
+				t.Errorf("flavourOf() = %v, want %v", got.qualifier(), tt.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedFeature(t *testing.T) {
+	_, err := vendors["tem"].listAssets(context.Background(), flavour{vendor: "tem", javafx: true})
+	if err == nil {
+		t.Errorf("listAssets() expected error for javafx of Temurin")
+	}
+}
@@ -23,10 +23,13 @@
 package java
 
 import (
+	"context"
 	"encoding/json"
+	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"io"
-	"net/http"
 )
 
 type ExtendedPackage struct {
@@ -34,9 +37,22 @@ type ExtendedPackage struct {
 	Sha256 string `json:"sha256_hash"`
 }
 
-func getExtendedPackage(packageId string) (ExtendedPackage, error) {
+func verifyZuluChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	extendedPackage, err := getExtendedPackage(ctx, asset.ExtraProperties["packageId"])
+	if err != nil {
+		return err
+	}
+	return verification.VerifySha256(fetchedPackage.FilePath, extendedPackage.Sha256)
+}
+
+// verifySha256Property verifies checksum delivered by the listing API together with the asset
+func verifySha256Property(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+	return verification.VerifySha256(fetchedPackage.FilePath, asset.ExtraProperties["sha256"])
+}
+
+func getExtendedPackage(ctx context.Context, packageId string) (ExtendedPackage, error) {
 	url := PackagesAPIURL + "/" + packageId
-	resp, err := http.Get(url)
+	resp, err := web.Get(ctx, url)
 	if err != nil {
 		return ExtendedPackage{}, err
 	}
@@ -44,7 +60,7 @@ func getExtendedPackage(packageId string) (ExtendedPackage, error) {
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			console.Fatal(err)
+			console.Error(err)
 		}
 	}(resp.Body)
 	var extendedPackage ExtendedPackage
@@ -54,3 +70,35 @@ func getExtendedPackage(packageId string) (ExtendedPackage, error) {
 	}
 	return extendedPackage, nil
 }
+
+func getJson(ctx context.Context, url string, target any) error {
+	resp, err := web.Get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			console.Error(err)
+		}
+	}(resp.Body)
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+func getText(ctx context.Context, url string) (string, error) {
+	resp, err := web.Get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			console.Error(err)
+		}
+	}(resp.Body)
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
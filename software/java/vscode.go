@@ -0,0 +1,318 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const vsCodeRuntimesSetting = "java.configuration.runtimes"
+
+type vsCodeRuntime struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Default bool   `json:"default,omitempty"`
+}
+
+func vsCodeUserDir() (string, error) {
+	current, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("APPDATA"), "Code", "User"), nil
+	case "darwin":
+		return filepath.Join(current.HomeDir, "Library", "Application Support", "Code", "User"), nil
+	default:
+		return filepath.Join(current.HomeDir, ".config", "Code", "User"), nil
+	}
+}
+
+// updateVsCodeSettings lists installed JDKs in java.configuration.runtimes of VS Code user settings,
+// nothing is done if VS Code has never been used
+func updateVsCodeSettings(installedPackages domain.InstalledPackages) error {
+	userDir, err := vsCodeUserDir()
+	if err != nil {
+		return err
+	}
+	exists, err := file.DirExists(userDir)
+	if err != nil || !exists {
+		return err
+	}
+	settingsPath := filepath.Join(userDir, "settings.json")
+	console.Info(fmt.Sprintf("Updating VS Code java runtimes in %s", settingsPath))
+	content := ""
+	exists, err = file.FileExists(settingsPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		content, err = file.ReadFile(settingsPath)
+		if err != nil {
+			return err
+		}
+	}
+	runtimes, err := vsCodeRuntimes(installedPackages)
+	if err != nil {
+		return err
+	}
+	newContent, err := mergeVsCodeSettings(content, runtimes, managedDir())
+	if err != nil {
+		return fmt.Errorf("cannot update %s: %w", settingsPath, err)
+	}
+	return file.OverrideFileWithContent(settingsPath, []string{newContent})
+}
+
+// vsCodeRuntimes returns JDK per execution environment (JavaSE-21 etc.), main version or the newest one is taken,
+// JREs are skipped as they cannot compile
+func vsCodeRuntimes(installedPackages domain.InstalledPackages) ([]vsCodeRuntime, error) {
+	var runtimes []vsCodeRuntime
+	indexes := make(map[string]int)
+	chosen := make(map[string]domain.InstalledPackage)
+	for _, installedPackage := range installedPackages.Items {
+		f, err := parseFlavour(installedPackage.Version.Distribution())
+		if err != nil {
+			return nil, err
+		}
+		if f.jre {
+			continue
+		}
+		name := executionEnvironment(installedPackage.Version.Major())
+		r := vsCodeRuntime{Name: name, Path: installedPackage.Path, Default: installedPackage.Main}
+		if index, ok := indexes[name]; !ok {
+			indexes[name] = len(runtimes)
+			runtimes = append(runtimes, r)
+			chosen[name] = installedPackage
+		} else if current := chosen[name]; !current.Main &&
+			(installedPackage.Main || domain.CompareDesc(installedPackage.Version, current.Version)) {
+			runtimes[index] = r
+			chosen[name] = installedPackage
+		}
+	}
+	return runtimes, nil
+}
+
+func executionEnvironment(major int) string {
+	if major <= 8 {
+		return fmt.Sprintf("JavaSE-1.%d", major)
+	}
+	return fmt.Sprintf("JavaSE-%d", major)
+}
+
+// mergeVsCodeSettings sets runtimes in settings with comments, runtimes added by hand outside managedDir
+// and other settings are kept, runtime added by hand wins over installed JDK of the same execution environment
+func mergeVsCodeSettings(content string, runtimes []vsCodeRuntime, managedDir string) (string, error) {
+	if strings.TrimSpace(content) == "" {
+		value, err := renderVsCodeRuntimes(nil, runtimes)
+		if err != nil {
+			return "", err
+		}
+		return "{\n    \"" + vsCodeRuntimesSetting + "\": " + value + "\n}\n", nil
+	}
+	tokens, err := tokenizeJsonc(content)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) < 2 || tokens[0].text(content) != "{" || tokens[len(tokens)-1].text(content) != "}" {
+		return "", errors.New("settings are not a JSON object")
+	}
+
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		text := tokens[i].text(content)
+		switch text {
+		case "{", "[":
+			depth++
+			continue
+		case "}", "]":
+			depth--
+			continue
+		}
+		if depth != 1 || !strings.HasPrefix(text, `"`) || i+2 >= len(tokens) || tokens[i+1].text(content) != ":" {
+			continue
+		}
+		var key string
+		if json.Unmarshal([]byte(text), &key) != nil || key != vsCodeRuntimesSetting {
+			continue
+		}
+		start, end := tokens[i+2].start, tokens[valueEnd(tokens, content, i+2)].end
+		existing, err := userVsCodeRuntimes(tokens, content, i+2, managedDir)
+		if err != nil {
+			return "", err
+		}
+		value, err := renderVsCodeRuntimes(existing, runtimes)
+		if err != nil {
+			return "", err
+		}
+		return content[:start] + value + content[end:], nil
+	}
+
+	value, err := renderVsCodeRuntimes(nil, runtimes)
+	if err != nil {
+		return "", err
+	}
+	last := tokens[len(tokens)-2]
+	separator := ","
+	if text := last.text(content); text == "{" || text == "," {
+		separator = ""
+	}
+	return content[:last.end] + separator + "\n    \"" + vsCodeRuntimesSetting + "\": " + value + content[last.end:], nil
+}
+
+// userVsCodeRuntimes returns runtimes of the setting which were not added by soft-ver-man
+func userVsCodeRuntimes(tokens []jsoncToken, content string, start int, managedDir string) ([]json.RawMessage, error) {
+	var compact strings.Builder
+	end := valueEnd(tokens, content, start)
+	for i := start; i <= end; i++ {
+		text := tokens[i].text(content)
+		// trailing commas are allowed in settings, but not in JSON
+		if text == "," && i < end && (tokens[i+1].text(content) == "]" || tokens[i+1].text(content) == "}") {
+			continue
+		}
+		compact.WriteString(text)
+	}
+	var existing []json.RawMessage
+	err := json.Unmarshal([]byte(compact.String()), &existing)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a list: %w", vsCodeRuntimesSetting, err)
+	}
+	var kept []json.RawMessage
+	for _, raw := range existing {
+		var r vsCodeRuntime
+		if json.Unmarshal(raw, &r) == nil && isManaged(r.Path, managedDir) {
+			continue
+		}
+		kept = append(kept, raw)
+	}
+	return kept, nil
+}
+
+func renderVsCodeRuntimes(kept []json.RawMessage, runtimes []vsCodeRuntime) (string, error) {
+	values := make([]interface{}, 0, len(kept)+len(runtimes))
+	userEnvironments := make(map[string]bool)
+	for _, raw := range kept {
+		var r vsCodeRuntime
+		if json.Unmarshal(raw, &r) == nil {
+			userEnvironments[r.Name] = true
+		}
+		values = append(values, raw)
+	}
+	for _, r := range runtimes {
+		if !userEnvironments[r.Name] {
+			values = append(values, r)
+		}
+	}
+	rendered, err := json.MarshalIndent(values, "    ", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+// jsoncToken is string, literal or punctuation of JSON with comments, as used by VS Code settings
+type jsoncToken struct {
+	start, end int
+}
+
+func (t jsoncToken) text(content string) string {
+	return content[t.start:t.end]
+}
+
+func tokenizeJsonc(content string) ([]jsoncToken, error) {
+	var tokens []jsoncToken
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			next := strings.IndexByte(content[i:], '\n')
+			if next < 0 {
+				i = len(content)
+			} else {
+				i += next
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			next := strings.Index(content[i+2:], "*/")
+			if next < 0 {
+				return nil, errors.New("unterminated comment")
+			}
+			i += next + 4
+		case c == '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(content) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, jsoncToken{start: i, end: end + 1})
+			i = end + 1
+		case strings.IndexByte("{}[]:,", c) >= 0:
+			tokens = append(tokens, jsoncToken{start: i, end: i + 1})
+			i++
+		default:
+			end := i
+			for end < len(content) && strings.IndexByte("{}[]:,\" \t\r\n/", content[end]) < 0 {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %c", c)
+			}
+			tokens = append(tokens, jsoncToken{start: i, end: end})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// valueEnd returns index of the last token of the value starting at token start
+func valueEnd(tokens []jsoncToken, content string, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].text(content) {
+		case "{", "[":
+			depth++
+		case "}", "]":
+			depth--
+		}
+		if depth == 0 {
+			return i
+		}
+	}
+	return len(tokens) - 1
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package java
+
+import (
+	"encoding/json"
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func TestVsCodeRuntimes(t *testing.T) {
+	version := func(value string) domain.Version {
+		v, _ := domain.NewVersion(value)
+		return v
+	}
+	got, err := vsCodeRuntimes(domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: version("21.0.2-tem"), Path: "/pf/java/tem21"},
+		{Version: version("21.0.1"), Path: "/pf/java/zulu21", Main: true},
+		{Version: version("17.0.10-jre"), Path: "/pf/java/zulu17-jre"},
+		{Version: version("8.0.402"), Path: "/pf/java/zulu8"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []vsCodeRuntime{
+		{Name: "JavaSE-21", Path: "/pf/java/zulu21", Default: true},
+		{Name: "JavaSE-1.8", Path: "/pf/java/zulu8"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("vsCodeRuntimes() = %v, want %v", got, want)
+	}
+}
+
+func TestVsCodeRuntimes_newestWithoutMain(t *testing.T) {
+	version := func(value string) domain.Version {
+		v, _ := domain.NewVersion(value)
+		return v
+	}
+	got, err := vsCodeRuntimes(domain.InstalledPackages{Items: []domain.InstalledPackage{
+		{Version: version("21.0.1"), Path: "/pf/java/zulu21.0.1"},
+		{Version: version("21.0.3"), Path: "/pf/java/zulu21.0.3"},
+		{Version: version("21.0.2"), Path: "/pf/java/zulu21.0.2"},
+		{Version: version("17.0.10-jre"), Path: "/pf/java/zulu17-jre"},
+		{Version: version("17.0.9"), Path: "/pf/java/zulu17"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []vsCodeRuntime{
+		{Name: "JavaSE-21", Path: "/pf/java/zulu21.0.3"},
+		{Name: "JavaSE-17", Path: "/pf/java/zulu17"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("vsCodeRuntimes() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeVsCodeSettings(t *testing.T) {
+	runtimes := []vsCodeRuntime{{Name: "JavaSE-21", Path: "/pf/java/zulu21", Default: true}}
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]interface{}
+	}{
+		{
+			name:    "empty settings",
+			content: "",
+			want: map[string]interface{}{vsCodeRuntimesSetting: []interface{}{
+				map[string]interface{}{"name": "JavaSE-21", "path": "/pf/java/zulu21", "default": true},
+			}},
+		},
+		{
+			name:    "setting added to other settings with comments",
+			content: "{\n    // font\n    \"editor.fontSize\": 14,\n    \"files.exclude\": {\"**/.git\": true}, /* trailing */\n}\n",
+			want: map[string]interface{}{
+				"editor.fontSize": 14.0,
+				"files.exclude":   map[string]interface{}{"**/.git": true},
+				vsCodeRuntimesSetting: []interface{}{
+					map[string]interface{}{"name": "JavaSE-21", "path": "/pf/java/zulu21", "default": true},
+				},
+			},
+		},
+		{
+			name: "setting replaced keeping runtimes added by hand even for installed execution environment",
+			content: `{
+    "java.configuration.runtimes": [
+        {"name": "JavaSE-11", "path": "/opt/jdk11", "sources": "/opt/src"},
+        {"name": "JavaSE-21", "path": "/opt/jdk21"},
+        {"name": "JavaSE-17", "path": "/pf/java/zulu17"}, // uninstalled
+    ],
+    "editor.fontSize": 14
+}`,
+			want: map[string]interface{}{
+				"editor.fontSize": 14.0,
+				vsCodeRuntimesSetting: []interface{}{
+					map[string]interface{}{"name": "JavaSE-11", "path": "/opt/jdk11", "sources": "/opt/src"},
+					map[string]interface{}{"name": "JavaSE-21", "path": "/opt/jdk21"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeVsCodeSettings(tt.content, runtimes, "/pf/java")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var compact []byte
+			tokens, err := tokenizeJsonc(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, token := range tokens {
+				text := token.text(merged)
+				if text == "," && i+1 < len(tokens) && (tokens[i+1].text(merged) == "}" || tokens[i+1].text(merged) == "]") {
+					continue
+				}
+				compact = append(compact, text...)
+			}
+			var got map[string]interface{}
+			err = json.Unmarshal(compact, &got)
+			if err != nil {
+				t.Fatalf("merged settings %v: %v", merged, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeVsCodeSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
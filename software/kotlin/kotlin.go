@@ -22,42 +22,29 @@
 package kotlin
 
 import (
-	"errors"
+	"context"
 	"github.com/pkk82/soft-ver-man/domain"
 )
 
-func init() {
-	var plugin = domain.Plugin{
-		Name:               Name,
-		EnvNamePrefix:      EnvNamePrefix,
-		EnvNameSuffix:      EnvNameSuffix,
-		GetAvailableAssets: getAvailableAssets,
-		VerifyChecksum: func(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
-			return errors.New("verify checksum not supported")
-		},
-		PostUninstall: func(version domain.Version) error {
-			return nil
-		},
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return nil
-		},
-		ExtraVariables: func(homeDir string) domain.EnvVariables {
-			return domain.EnvVariables{}
-		},
-		CalculateDownloadedFileName: calculateDownloadFileName,
-		ExtractStrategy:             domain.ReplaceCompressedDirWithArchiveName,
-		ExecutableRelativePath:      "bin",
-		VersionGranularity:          domain.VersionGranularityMinor,
-	}
-	domain.Register(plugin)
+type plugin struct {
+	domain.PluginInfo
 }
 
-func calculateDownloadFileName(asset domain.Asset) string {
-	return asset.Name
+func init() {
+	domain.Register(plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   Name,
+			EnvNamePrefix:          EnvNamePrefix,
+			EnvNameSuffix:          EnvNameSuffix,
+			ExtractStrategy:        domain.ReplaceCompressedDirWithArchiveName,
+			ExecutableRelativePath: "bin",
+			VersionGranularity:     domain.VersionGranularityMinor,
+		},
+	})
 }
 
-func getAvailableAssets() ([]domain.Asset, error) {
-	packages, err := getSupportedPackages()
+func (plugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	packages, err := getSupportedPackages(ctx)
 	if err != nil {
 		return nil, err
 	}
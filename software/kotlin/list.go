@@ -23,6 +23,7 @@
 package kotlin
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/util/github"
 	"strings"
 )
@@ -31,6 +32,6 @@ var predicate = func(name string) bool {
 	return strings.HasPrefix(name, "kotlin-compiler") && strings.HasSuffix(name, ".zip")
 }
 
-func getSupportedPackages() ([]github.Asset, error) {
-	return github.GetSupportedAssets(RepoOwner, RepoName, PageSize, predicate)
+func getSupportedPackages(ctx context.Context) ([]github.Asset, error) {
+	return github.GetSupportedAssets(ctx, RepoOwner, RepoName, PageSize, predicate)
 }
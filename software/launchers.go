@@ -0,0 +1,207 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"os"
+	"text/tabwriter"
+)
+
+// registerLauncher adds launcher of installed package to the registry, the launcher is created on next refresh
+func registerLauncher(plugin domain.Plugin, installedPackage domain.InstalledPackage) error {
+	if _, ok := plugin.(domain.Launcher); !ok {
+		return nil
+	}
+	launchers, err := loadLaunchers()
+	if err != nil {
+		return err
+	}
+	for _, launcher := range launchers {
+		if launcher.Software == plugin.Info().Name && launcher.Version == installedPackage.Version.Value {
+			return nil
+		}
+	}
+	launchers = append(launchers, domain.RegisteredLauncher{Software: plugin.Info().Name, Version: installedPackage.Version.Value})
+	return config.StoreLaunchers(launchers)
+}
+
+// loadLaunchers reads the registry, launchers of all installed packages are registered if the registry is used first time
+func loadLaunchers() ([]domain.RegisteredLauncher, error) {
+	if config.IsLaunchersRegistryInitialized() {
+		return config.LoadLaunchers()
+	}
+	allInstalledPackages, err := config.LoadAllInstalledPackages()
+	if err != nil {
+		return nil, err
+	}
+	var launchers []domain.RegisteredLauncher
+	for _, installedPackages := range allInstalledPackages {
+		if _, ok := installedPackages.Plugin.(domain.Launcher); !ok {
+			continue
+		}
+		for _, installedPackage := range installedPackages.Items {
+			launchers = append(launchers, domain.RegisteredLauncher{Software: installedPackages.Plugin.Info().Name, Version: installedPackage.Version.Value})
+		}
+	}
+	return launchers, nil
+}
+
+// RefreshLaunchers regenerates registered launchers with current environment,
+// launchers of packages which are not installed anymore are deleted
+func RefreshLaunchers() error {
+	launchers, err := loadLaunchers()
+	if err != nil {
+		return err
+	}
+	if len(launchers) == 0 {
+		return nil
+	}
+	allInstalledPackages := make(map[string]domain.InstalledPackages)
+	var refreshed []domain.RegisteredLauncher
+	for _, launcher := range launchers {
+		installedPackages, ok := allInstalledPackages[launcher.Software]
+		if !ok {
+			installedPackages, err = config.LoadInstalledPackages(launcher.Software)
+			if err != nil {
+				return err
+			}
+			allInstalledPackages[launcher.Software] = installedPackages
+		}
+		plugin, isLauncher := installedPackages.Plugin.(domain.Launcher)
+		installedPackage, found := findInstalledVersion(installedPackages, launcher.Version)
+		if !isLauncher || !found {
+			err = deleteLauncherFile(launcher)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		launcher.Path, err = plugin.CreateLauncher(installedPackage, installedPackages)
+		if err != nil {
+			return err
+		}
+		refreshed = append(refreshed, launcher)
+	}
+	return config.StoreLaunchers(refreshed)
+}
+
+// RemoveLaunchers deletes launchers of the software whose versions match the constraint, all of them if it is empty,
+// removed launchers are not regenerated anymore
+func RemoveLaunchers(plugin domain.Plugin, inputVersion string) error {
+	launchers, err := loadLaunchers()
+	if err != nil {
+		return err
+	}
+	kept, removed, err := splitLaunchers(launchers, plugin.Info().Name, resolveSelector(plugin, inputVersion))
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		return errors.New("no launchers of " + plugin.Info().Name + " " + inputVersion + " registered")
+	}
+	for _, launcher := range removed {
+		err = deleteLauncherFile(launcher)
+		if err != nil {
+			return err
+		}
+	}
+	return config.StoreLaunchers(kept)
+}
+
+// splitLaunchers matches versions of registered launchers, not of installed packages,
+// so that launchers of already uninstalled packages can be removed too
+func splitLaunchers(launchers []domain.RegisteredLauncher, software, selector string) ([]domain.RegisteredLauncher, []domain.RegisteredLauncher, error) {
+	var constraint *domain.Constraint
+	if selector != "" {
+		parsed, err := domain.ParseConstraint(selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		constraint = &parsed
+	}
+	var kept, removed []domain.RegisteredLauncher
+	for _, launcher := range launchers {
+		if launcher.Software != software {
+			kept = append(kept, launcher)
+			continue
+		}
+		if constraint != nil {
+			version, err := domain.NewVersion(launcher.Version)
+			if err != nil || !constraint.Matches(version) {
+				kept = append(kept, launcher)
+				continue
+			}
+		}
+		removed = append(removed, launcher)
+	}
+	return kept, removed, nil
+}
+
+func DisplayLaunchers() error {
+	launchers, err := loadLaunchers()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', tabwriter.Debug)
+	if len(launchers) == 0 {
+		_, err = fmt.Fprintln(w, "There are no launchers")
+	} else {
+		_, err = fmt.Fprintln(w, "Software\t Version\t Path")
+	}
+	if err != nil {
+		return err
+	}
+	for _, launcher := range launchers {
+		_, err = fmt.Fprintf(w, "%s\t %s\t %s\n", launcher.Software, launcher.Version, launcher.Path)
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func findInstalledVersion(installedPackages domain.InstalledPackages, version string) (domain.InstalledPackage, bool) {
+	for _, installedPackage := range installedPackages.Items {
+		if installedPackage.Version.Value == version {
+			return installedPackage, true
+		}
+	}
+	return domain.InstalledPackage{}, false
+}
+
+func deleteLauncherFile(launcher domain.RegisteredLauncher) error {
+	if launcher.Path == "" {
+		return nil
+	}
+	console.Info(fmt.Sprintf("Deleting launcher %s", launcher.Path))
+	err := os.Remove(launcher.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"reflect"
+	"testing"
+)
+
+func Test_splitLaunchers(t *testing.T) {
+	launchers := []domain.RegisteredLauncher{
+		{Software: "intellij-idea", Version: "2024.1.2"},
+		{Software: "intellij-idea", Version: "2024.1.4"},
+		{Software: "intellij-idea", Version: "2024.2.1"},
+		{Software: "goland", Version: "2024.1.2"},
+	}
+	tests := []struct {
+		name        string
+		selector    string
+		wantRemoved []domain.RegisteredLauncher
+	}{
+		{name: "all", selector: "", wantRemoved: launchers[:3]},
+		{name: "exact", selector: "2024.1.2", wantRemoved: launchers[:1]},
+		{name: "every matching prefix", selector: "2024.1", wantRemoved: launchers[:2]},
+		{name: "every matching range", selector: ">=2024.1.4", wantRemoved: launchers[1:3]},
+		{name: "none", selector: "2023", wantRemoved: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, removed, err := splitLaunchers(launchers, "intellij-idea", tt.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("splitLaunchers() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+			if len(kept)+len(removed) != len(launchers) {
+				t.Errorf("splitLaunchers() kept = %v", kept)
+			}
+		})
+	}
+}
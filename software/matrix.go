@@ -0,0 +1,139 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	svmio "github.com/pkk82/soft-ver-man/util/io"
+	"github.com/pkk82/soft-ver-man/util/process"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type MatrixResult struct {
+	Version  string
+	ExitCode int
+	Err      error
+	Duration time.Duration
+}
+
+func (result MatrixResult) Passed() bool {
+	return result.Err == nil && result.ExitCode == 0
+}
+
+// Matrix runs command with each of the versions of software, missing versions are installed first
+func Matrix(ctx context.Context, plugin domain.Plugin, versions []string, command string, args []string, parallel bool) ([]MatrixResult, error) {
+	for _, version := range versions {
+		err := installIfMissing(ctx, plugin, version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]MatrixResult, len(versions))
+	if !parallel {
+		for i, version := range versions {
+			console.Info(fmt.Sprintf("=== %v %v", plugin.Info().Name, version))
+			results[i] = runMatrixItem(ctx, plugin, version, command, args, os.Stdin, os.Stdout, os.Stderr)
+		}
+		return results, nil
+	}
+
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	for i, version := range versions {
+		waitGroup.Add(1)
+		go func(i int, version string) {
+			defer waitGroup.Done()
+			prefix := fmt.Sprintf("[%v %v] ", plugin.Info().Name, version)
+			stdout := svmio.NewPrefixWriter(os.Stdout, prefix, &mutex)
+			stderr := svmio.NewPrefixWriter(os.Stderr, prefix, &mutex)
+			results[i] = runMatrixItem(ctx, plugin, version, command, args, nil, stdout, stderr)
+			_ = stdout.Flush()
+			_ = stderr.Flush()
+		}(i, version)
+	}
+	waitGroup.Wait()
+	return results, nil
+}
+
+func installIfMissing(ctx context.Context, plugin domain.Plugin, version string) error {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	_, err = findInstalledPackage(installedPackages, version)
+	if err == nil {
+		return nil
+	}
+	console.Info(fmt.Sprintf("Installing missing %v %v", plugin.Info().Name, version))
+	_, verifyChecksum := plugin.(domain.ChecksumVerifier)
+	return Install(ctx, plugin, version, InstallOptions{VerifyChecksum: &verifyChecksum})
+}
+
+func runMatrixItem(ctx context.Context, plugin domain.Plugin, version, command string, args []string, stdin io.Reader, stdout, stderr io.Writer) MatrixResult {
+	start := time.Now()
+	result := MatrixResult{Version: version, ExitCode: -1}
+	environ, err := execEnviron(ctx, []ExecItem{{Plugin: plugin, Version: version}}, os.Environ())
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	executable, err := process.LookPath(command, environ)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.ExitCode, result.Err = process.RunWithStreams(executable, args, environ, stdin, stdout, stderr)
+	result.Duration = time.Since(start)
+	return result
+}
+
+func DisplayMatrixResults(plugin domain.Plugin, results []MatrixResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', tabwriter.Debug)
+	_, err := fmt.Fprintln(w, "Software\t Version\t Result\t Exit code\t Duration")
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed() {
+			status = "FAIL"
+		}
+		if result.Err != nil {
+			status = "ERROR: " + result.Err.Error()
+		}
+		_, err = fmt.Fprintf(w, "%s\t %s\t %s\t %d\t %s\n", plugin.Info().Name, result.Version, status, result.ExitCode, result.Duration.Round(time.Millisecond))
+		if err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
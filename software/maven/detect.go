@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package maven
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"path/filepath"
+	"regexp"
+)
+
+var wrapperPropertiesPath = filepath.Join(".mvn", "wrapper", "maven-wrapper.properties")
+
+var distributionUrlVersion = regexp.MustCompile(`apache-maven-([^/]+)-bin\.(zip|tar\.gz)$`)
+
+func (plugin) DetectProjectVersion(dir string) (domain.ProjectVersion, bool, error) {
+	projectVersion, found, err := project.ReadSdkmanrc(dir, "maven")
+	if err != nil || found {
+		return projectVersion, found, err
+	}
+	filePath := filepath.Join(dir, wrapperPropertiesPath)
+	properties, exists, err := project.ReadProperties(filePath)
+	if err != nil || !exists {
+		return domain.ProjectVersion{}, false, err
+	}
+	match := distributionUrlVersion.FindStringSubmatch(properties["distributionUrl"])
+	if match == nil {
+		return domain.ProjectVersion{}, false, nil
+	}
+	return domain.ProjectVersion{Version: match[1], File: filePath}, true, nil
+}
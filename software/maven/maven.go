@@ -22,48 +22,38 @@
 package maven
 
 import (
-	"errors"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"strconv"
 )
 
+type plugin struct {
+	domain.PluginInfo
+}
+
 func init() {
-	var plugin = domain.Plugin{
-		Name:          Name,
-		EnvNamePrefix: EnvNamePrefix,
-		EnvNameSuffix: EnvNameSuffix,
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return nil
-		},
-		PostUninstall: func(version domain.Version) error {
-			return nil
-		},
-		VerifyChecksum: func(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
-			return errors.New("verify checksum not supported")
-		},
-		GetAvailableAssets: func() ([]domain.Asset, error) {
-			return []domain.Asset{}, errors.New("get supported packages not supported")
+	domain.Register(plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   Name,
+			EnvNamePrefix:          EnvNamePrefix,
+			EnvNameSuffix:          EnvNameSuffix,
+			ExecutableRelativePath: "bin",
+			VersionGranularity:     domain.VersionGranularityMajor,
+			ExtractStrategy:        domain.UseCompressedDirOrArchiveName,
 		},
-		CalculateDownloadUrl:        calculateDownloadUrl,
-		CalculateDownloadedFileName: calculateDownloadedFileName,
-		ExecutableRelativePath:      "bin",
-		VersionGranularity:          domain.VersionGranularityMajor,
-		ExtractStrategy:             domain.UseCompressedDirOrArchiveName,
-	}
-	domain.Register(plugin)
+	})
 }
 
-func calculateDownloadUrl(version domain.Version, _ string, _ string) (string, domain.Type) {
+func (plugin) CalculateDownloadUrl(version domain.Version, _ string, _ string) (string, domain.Type, error) {
 	extension := toExtension()
 	url := fmt.Sprintf(DownloadURL, strconv.Itoa(version.Major()), version.Value, version.Value, extension)
-	return url, extension
+	return url, extension, nil
 }
 
 func toExtension() domain.Type {
 	return domain.ZIP
 }
 
-func calculateDownloadedFileName(asset domain.Asset) string {
+func (plugin) CalculateDownloadedFileName(asset domain.Asset) string {
 	return fmt.Sprintf("apache-maven-%s.%s", asset.Version, asset.Type)
 }
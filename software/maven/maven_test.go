@@ -26,11 +26,13 @@ import (
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/software/maven"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"path/filepath"
 	"testing"
 )
 
 func Test_calculateDownloadUrl(t *testing.T) {
-	plugin := domain.GetPlugin(maven.Name)
+	plugin := domain.GetPlugin(maven.Name).(domain.DownloadUrlCalculator)
 
 	tests := []struct {
 		version      domain.Version
@@ -56,7 +58,10 @@ func Test_calculateDownloadUrl(t *testing.T) {
 	for _, tt := range tests {
 		name := fmt.Sprintf("Checking download path for version %s, os %s and arch %s", tt.version.Value, tt.os, tt.arch)
 		t.Run(name, func(t *testing.T) {
-			actualPath, actualType := plugin.CalculateDownloadUrl(tt.version, tt.os, tt.arch)
+			actualPath, actualType, err := plugin.CalculateDownloadUrl(tt.version, tt.os, tt.arch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 			if actualPath != tt.expectedPath {
 				t.Errorf("calculateDownloadPath().path = %v, want %v", actualPath, tt.expectedPath)
 			}
@@ -66,3 +71,22 @@ func Test_calculateDownloadUrl(t *testing.T) {
 		})
 	}
 }
+
+func Test_detectProjectVersion(t *testing.T) {
+	plugin := domain.GetPlugin(maven.Name).(domain.ProjectVersionDetector)
+	dir := test.CreateTestDir(t)
+	wrapperDir := filepath.Join(dir, ".mvn", "wrapper")
+	test.CreateDir(wrapperDir, t)
+	test.CreateFile(wrapperDir, "maven-wrapper.properties", []string{
+		"wrapperVersion=3.3.2",
+		"distributionUrl=https://repo.maven.apache.org/maven2/org/apache/maven/apache-maven/3.9.6/apache-maven-3.9.6-bin.zip",
+	}, t)
+
+	projectVersion, found, err := plugin.DetectProjectVersion(dir)
+	if err != nil || !found {
+		t.Fatalf("DetectProjectVersion() found = %v, err = %v", found, err)
+	}
+	if projectVersion.Version != "3.9.6" || projectVersion.File != filepath.Join(wrapperDir, "maven-wrapper.properties") {
+		t.Errorf("DetectProjectVersion() = %v", projectVersion)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"path/filepath"
+)
+
+var versionFileNames = []string{".nvmrc", ".node-version"}
+
+const packageJsonFileName = "package.json"
+
+func (plugin) DetectProjectVersion(dir string) (domain.ProjectVersion, bool, error) {
+	for _, fileName := range versionFileNames {
+		projectVersion, found, err := project.ReadVersionFile(dir, fileName)
+		if err != nil || found {
+			return projectVersion, found, err
+		}
+	}
+	return readPackageJsonEngines(dir)
+}
+
+type packageJson struct {
+	Engines struct {
+		Node string `json:"node"`
+	} `json:"engines"`
+}
+
+func readPackageJsonEngines(dir string) (domain.ProjectVersion, bool, error) {
+	filePath := filepath.Join(dir, packageJsonFileName)
+	exists, err := file.FileExists(filePath)
+	if err != nil || !exists {
+		return domain.ProjectVersion{}, false, err
+	}
+	content, err := file.ReadFile(filePath)
+	if err != nil {
+		return domain.ProjectVersion{}, false, err
+	}
+	version, err := parseEnginesNode(content)
+	if err != nil {
+		// package.json is not only about node version, so broken one does not stop looking further
+		console.Warn(fmt.Errorf("%v: %w", filePath, err))
+		return domain.ProjectVersion{}, false, nil
+	}
+	if version == "" {
+		return domain.ProjectVersion{}, false, nil
+	}
+	return domain.ProjectVersion{Version: version, File: filePath}, true, nil
+}
+
+func parseEnginesNode(content string) (string, error) {
+	var model packageJson
+	err := json.Unmarshal([]byte(content), &model)
+	if err != nil {
+		return "", err
+	}
+	return model.Engines.Node, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package node
+
+import (
+	"bytes"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_parseEnginesNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "no engines", content: `{"name": "app"}`, want: ""},
+		{name: "engines without node", content: `{"engines": {"npm": ">=9"}}`, want: ""},
+		{name: "engines with node", content: `{"name": "app", "engines": {"node": ">=18.0.0", "npm": ">=9"}}`, want: ">=18.0.0"},
+		{name: "not a json", content: `engines`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEnginesNode(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseEnginesNode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseEnginesNode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readPackageJsonEngines_broken(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	test.CreateFile(dir, packageJsonFileName, []string{"{\"engines\": "}, t)
+	var output bytes.Buffer
+	console.SetOutput(&output)
+	defer console.SetOutput(os.Stderr)
+
+	_, found, err := readPackageJsonEngines(dir)
+	if err != nil || found {
+		t.Errorf("readPackageJsonEngines() found = %v, error = %v, want skipped file", found, err)
+	}
+	if !strings.HasPrefix(output.String(), "Warning: ") {
+		t.Errorf("readPackageJsonEngines() output = %q, want warning", output.String())
+	}
+}
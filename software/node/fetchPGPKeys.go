@@ -23,16 +23,21 @@
 package node
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/util/download"
 	"strings"
 )
 
-func fetchPGPKeys(softwareDownloadDir string) []string {
+func fetchPGPKeys(ctx context.Context, softwareDownloadDir string) ([]string, error) {
 	var paths = make([]string, 0)
 	for _, fingerprint := range getFingerprints() {
-		paths = append(paths, download.FetchFileSilently("https://keys.openpgp.org/vks/v1/by-fingerprint/"+fingerprint, softwareDownloadDir+"/node-pgp-keys", fingerprint))
+		path, err := download.FetchFileSilently(ctx, "https://keys.openpgp.org/vks/v1/by-fingerprint/"+fingerprint, softwareDownloadDir+"/node-pgp-keys", fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
 	}
-	return paths
+	return paths, nil
 }
 
 func getFingerprints() []string {
@@ -22,25 +22,45 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"io"
-	"net/http"
 	"runtime"
+	"strings"
 )
 
 type PackagesPerVersion struct {
 	Version string   `json:"version"`
 	Date    string   `json:"date"`
 	Files   []string `json:"files"`
+	// Lts is false or codename of LTS line, e.g. Iron
+	Lts any `json:"lts"`
 }
 
 type Package struct {
 	Version  string
 	FileName string
 	Type     domain.Type
+	Lts      string
+}
+
+func (v Package) Channels() []string {
+	if v.Lts == "" {
+		return nil
+	}
+	return []string{domain.ChannelLts, domain.ChannelLts + "/" + v.Lts}
+}
+
+func ltsCodename(lts any) string {
+	codename, ok := lts.(string)
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(codename)
 }
 
 func (v Package) DownloadLink() string {
@@ -54,23 +74,23 @@ func (v Package) SumsSigLink() string {
 	return fmt.Sprintf("%s/%s/%s", DistURL, v.Version, ShaSumSigFileName)
 }
 
-func getSupportedPackages() []Package {
-	resp, err := http.Get(JsonFileURL)
+func getSupportedPackages(ctx context.Context) ([]Package, error) {
+	resp, err := web.Get(ctx, JsonFileURL)
 	if err != nil {
-		console.Fatal(err)
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			console.Fatal(err)
+			console.Error(err)
 		}
 	}(resp.Body)
 	var filesPerVersions []PackagesPerVersion
 	err = json.NewDecoder(resp.Body).Decode(&filesPerVersions)
 	if err != nil {
-		console.Fatal(err)
+		return nil, err
 	}
-	return supportedPackages(&filesPerVersions, runtime.GOOS, runtime.GOARCH)
+	return supportedPackages(&filesPerVersions, runtime.GOOS, runtime.GOARCH), nil
 }
 
 func supportedPackages(packagesPerVersions *[]PackagesPerVersion, goOpSystem, goarch string) []Package {
@@ -83,6 +103,7 @@ func supportedPackages(packagesPerVersions *[]PackagesPerVersion, goOpSystem, go
 				Version:  packagePerVersion.Version,
 				FileName: fileName,
 				Type:     supportedType,
+				Lts:      ltsCodename(packagePerVersion.Lts),
 			}
 			result = append(result, version)
 		}
@@ -23,6 +23,7 @@
 package node
 
 import (
+	"encoding/json"
 	"github.com/pkk82/soft-ver-man/domain"
 	"reflect"
 	"testing"
@@ -124,6 +125,27 @@ func TestSupportedFiles(t *testing.T) {
 	}
 }
 
+func TestLtsChannels(t *testing.T) {
+	content := `[
+		{"version": "v21.6.1", "files": ["linux-x64"], "lts": false},
+		{"version": "v20.11.0", "files": ["linux-x64"], "lts": "Iron"}
+	]`
+	var filesPerVersions []PackagesPerVersion
+	err := json.Unmarshal([]byte(content), &filesPerVersions)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	actual := supportedPackages(&filesPerVersions, "linux", "amd64")
+	if actual[0].Channels() != nil {
+		t.Errorf("Expected no channels, got: %v", actual[0].Channels())
+	}
+	expected := []string{"lts", "lts/iron"}
+	if !reflect.DeepEqual(actual[1].Channels(), expected) {
+		t.Errorf("Expected: %v, got: %v", expected, actual[1].Channels())
+	}
+}
+
 func TestDownloadLink(t *testing.T) {
 	versions := []Package{
 		{Version: "v0.8.6", FileName: "node-v0.8.6-darwin-x64.tar.gz"},
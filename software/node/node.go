@@ -22,6 +22,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/download"
@@ -29,33 +30,28 @@ import (
 	"path/filepath"
 )
 
-func init() {
-	var plugin = domain.Plugin{
-		Name:               Name,
-		EnvNamePrefix:      EnvNamePrefix,
-		EnvNameSuffix:      EnvNameSuffix,
-		GetAvailableAssets: getAvailableAssets,
-		VerifyChecksum:     verifyChecksum,
-		PostUninstall: func(version domain.Version) error {
-			return nil
-		},
-		PostInstall: func(installedPackage domain.InstalledPackage) error {
-			return nil
-		},
-		CalculateDownloadedFileName: calculateDownloadFileName,
-		ExtractStrategy:             domain.UseCompressedDirOrArchiveName,
-		ExecutableRelativePath:      "bin",
-		VersionGranularity:          domain.VersionGranularityMajor,
-	}
-	domain.Register(plugin)
+type plugin struct {
+	domain.PluginInfo
 }
 
-func calculateDownloadFileName(asset domain.Asset) string {
-	return asset.Name
+func init() {
+	domain.Register(plugin{
+		PluginInfo: domain.PluginInfo{
+			Name:                   Name,
+			EnvNamePrefix:          EnvNamePrefix,
+			EnvNameSuffix:          EnvNameSuffix,
+			ExtractStrategy:        domain.UseCompressedDirOrArchiveName,
+			ExecutableRelativePath: "bin",
+			VersionGranularity:     domain.VersionGranularityMajor,
+		},
+	})
 }
 
-func getAvailableAssets() ([]domain.Asset, error) {
-	packages := getSupportedPackages()
+func (plugin) GetAvailableAssets(ctx context.Context) ([]domain.Asset, error) {
+	packages, err := getSupportedPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
 	assets := make([]domain.Asset, len(packages))
 	for i, p := range packages {
 		assets[i] = domain.Asset{
@@ -64,20 +60,33 @@ func getAvailableAssets() ([]domain.Asset, error) {
 			Url:             p.DownloadLink(),
 			Type:            p.Type,
 			ExtraProperties: map[string]string{"sumsLink": p.SumsLink(), "sumsSigLink": p.SumsSigLink()},
+			Channels:        p.Channels(),
 		}
 	}
 	return assets, nil
 }
 
-func verifyChecksum(asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
+func (plugin) VerifyChecksum(ctx context.Context, asset domain.Asset, fetchedPackage domain.FetchedPackage) error {
 	nodeDownloadDir := filepath.Dir(fetchedPackage.FilePath)
 	softwareDownloadDir := filepath.Dir(nodeDownloadDir)
 	version := fetchedPackage.Version
-	publicKeyPaths := fetchPGPKeys(softwareDownloadDir)
+	publicKeyPaths, err := fetchPGPKeys(ctx, softwareDownloadDir)
+	if err != nil {
+		return err
+	}
 	shaSumFileName := fmt.Sprintf("%v-%v-%v", Name, version.Value, ShaSumFileName)
-	shaSumFilePath := download.FetchFileSilently(asset.ExtraProperties["sumsLink"], nodeDownloadDir, shaSumFileName)
+	shaSumFilePath, err := download.FetchFileSilently(ctx, asset.ExtraProperties["sumsLink"], nodeDownloadDir, shaSumFileName)
+	if err != nil {
+		return err
+	}
 	shaSumSigFileName := fmt.Sprintf("%v-%v-%v", Name, version.Value, ShaSumSigFileName)
-	shaSumSigFilePath := download.FetchFileSilently(asset.ExtraProperties["sumsSigLink"], nodeDownloadDir, shaSumSigFileName)
-	pgp.VerifySignature(shaSumFilePath, shaSumSigFilePath, publicKeyPaths)
+	shaSumSigFilePath, err := download.FetchFileSilently(ctx, asset.ExtraProperties["sumsSigLink"], nodeDownloadDir, shaSumSigFileName)
+	if err != nil {
+		return err
+	}
+	err = pgp.VerifySignature(shaSumFilePath, shaSumSigFilePath, publicKeyPaths)
+	if err != nil {
+		return err
+	}
 	return verifySha(fetchedPackage.FilePath, shaSumFilePath)
 }
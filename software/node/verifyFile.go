@@ -34,8 +34,11 @@ import (
 
 func verifySha(filePath, signatureFilePath string) error {
 
-	expectedHash := readHashes(signatureFilePath)[filepath.Base(filePath)]
-	err := verification.VerifySha256(filePath, expectedHash)
+	hashes, err := readHashes(signatureFilePath)
+	if err != nil {
+		return err
+	}
+	err = verification.VerifySha256(filePath, hashes[filepath.Base(filePath)])
 
 	if err == nil {
 		console.Info(filePath + " is correct file")
@@ -45,10 +48,10 @@ func verifySha(filePath, signatureFilePath string) error {
 	return err
 }
 
-func readHashes(signatureFilePath string) map[string]string {
+func readHashes(signatureFilePath string) (map[string]string, error) {
 	file, err := os.Open(signatureFilePath)
 	if err != nil {
-		console.Fatal(err)
+		return nil, err
 	}
 	defer func(file *os.File) {
 		err := file.Close()
@@ -57,17 +60,16 @@ func readHashes(signatureFilePath string) map[string]string {
 		}
 	}(file)
 
-	regex, err := regexp.Compile("\\s+")
-	if err != nil {
-		console.Fatal(err)
-	}
+	regex := regexp.MustCompile("\\s+")
 
 	scanner := bufio.NewScanner(file)
 	result := make(map[string]string)
 	for scanner.Scan() {
 		line := scanner.Text()
 		split := regex.Split(line, -1)
-		result[split[1]] = split[0]
+		if len(split) > 1 {
+			result[split[1]] = split[0]
+		}
 	}
-	return result
+	return result, scanner.Err()
 }
@@ -0,0 +1,203 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"github.com/pkk82/soft-ver-man/util/process"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const ShimsDirName = "shims"
+
+func ShimsDir() string {
+	return filepath.Join(viper.GetString(config.SoftwareDirKey), ShimsDirName)
+}
+
+// Reshim regenerates shims for executables of all installed packages
+func Reshim() error {
+	svmExecutable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	allInstalledPackages, err := config.LoadAllInstalledPackages()
+	if err != nil {
+		return err
+	}
+
+	shimsDir := ShimsDir()
+	err = os.RemoveAll(shimsDir)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(shimsDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	pluginByExecutable := make(map[string]string)
+	for _, installedPackages := range allInstalledPackages {
+		for _, installedPackage := range installedPackages.Items {
+			executables, err := findExecutables(installedPackages.Plugin, installedPackage)
+			if err != nil {
+				return err
+			}
+			for _, executable := range executables {
+				if _, ok := pluginByExecutable[executable]; !ok {
+					pluginByExecutable[executable] = installedPackages.Plugin.Info().Name
+				}
+			}
+		}
+	}
+
+	executables := make([]string, 0, len(pluginByExecutable))
+	for executable := range pluginByExecutable {
+		executables = append(executables, executable)
+	}
+	sort.Strings(executables)
+	for _, executable := range executables {
+		shimPath := filepath.Join(shimsDir, executable)
+		err = file.OverrideFileWithContent(shimPath, shimContent(svmExecutable, pluginByExecutable[executable], executable))
+		if err != nil {
+			return err
+		}
+		err = os.Chmod(shimPath, 0755)
+		if err != nil {
+			return err
+		}
+	}
+	console.Info(fmt.Sprintf("%d shims created in %v, add it to PATH of applications not reading shell rc files", len(executables), shimsDir))
+	return nil
+}
+
+// RemoveShims turns shims mode off
+func RemoveShims() error {
+	err := os.RemoveAll(ShimsDir())
+	if err != nil {
+		return err
+	}
+	return setShimsEnabled(false)
+}
+
+// EnableShims turns shims mode on, so shims are regenerated after each install and uninstall
+func EnableShims() error {
+	err := setShimsEnabled(true)
+	if err != nil {
+		return err
+	}
+	return Reshim()
+}
+
+func setShimsEnabled(enabled bool) error {
+	viper.Set(config.ShimsKey, enabled)
+	return viper.WriteConfig()
+}
+
+func reshimIfEnabled() error {
+	if !viper.GetBool(config.ShimsKey) {
+		return nil
+	}
+	return Reshim()
+}
+
+func shimContent(svmExecutable, pluginName, executable string) []string {
+	return []string{
+		"#!/bin/sh",
+		"# generated by soft-ver-man, run 'svm reshim' to regenerate",
+		fmt.Sprintf("exec \"%v\" shim-exec %v %v \"$@\"", svmExecutable, pluginName, executable),
+		"",
+	}
+}
+
+// RunShim runs executable of the plugin in the version wanted by the project in the current directory or in the main version
+func RunShim(plugin domain.Plugin, executable string, args []string) (int, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return -1, err
+	}
+	wantedVersions := findWantedVersions(dir)
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return -1, err
+	}
+	if len(installedPackages.Items) == 0 {
+		return -1, fmt.Errorf("%v is not installed", plugin.Info().Name)
+	}
+	installedPackage, err := wantedOrMain(installedPackages, wantedVersions[plugin.Info().Name])
+	if err != nil {
+		return -1, err
+	}
+
+	executablePath := filepath.Join(executablesDir(plugin, installedPackage), executable)
+	if isRawPackage(installedPackage) {
+		executablePath = installedPackage.Path
+	}
+	if _, err := os.Stat(executablePath); err != nil {
+		return -1, fmt.Errorf("%v not found in %v %v", executable, plugin.Info().Name, installedPackage.Version.Value)
+	}
+
+	environ, err := domain.DirectEnvVariables(plugin, installedPackage).ToEnviron(os.Environ())
+	if err != nil {
+		return -1, err
+	}
+	return process.Run(executablePath, args, environ)
+}
+
+func findExecutables(plugin domain.Plugin, installedPackage domain.InstalledPackage) ([]string, error) {
+	if isRawPackage(installedPackage) {
+		return []string{filepath.Base(installedPackage.Path)}, nil
+	}
+	entries, err := os.ReadDir(executablesDir(plugin, installedPackage))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	executables := make([]string, 0)
+	for _, entry := range entries {
+		info, err := os.Stat(filepath.Join(executablesDir(plugin, installedPackage), entry.Name()))
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+		executables = append(executables, entry.Name())
+	}
+	return executables, nil
+}
+
+func executablesDir(plugin domain.Plugin, installedPackage domain.InstalledPackage) string {
+	return filepath.Join(installedPackage.Path, plugin.Info().ExecutableRelativePath)
+}
+
+// isRawPackage checks if package was installed by copying single executable file
+func isRawPackage(installedPackage domain.InstalledPackage) bool {
+	info, err := os.Stat(installedPackage.Path)
+	return err == nil && !info.IsDir()
+}
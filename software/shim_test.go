@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_findExecutables(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	packageDir := filepath.Join(dir, "node-v20.1.3")
+	test.CreateDir(filepath.Join(packageDir, "bin", "lib"), t)
+	test.CreateFile(filepath.Join(packageDir, "bin"), "node", []string{"#!/bin/sh"}, t)
+	test.CreateFile(filepath.Join(packageDir, "bin"), "npm", []string{"#!/bin/sh"}, t)
+	test.CreateFile(filepath.Join(packageDir, "bin"), "README", []string{"readme"}, t)
+	for _, executable := range []string{"node", "npm"} {
+		err := os.Chmod(filepath.Join(packageDir, "bin", executable), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.Chmod(filepath.Join(packageDir, "bin", "README"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.CreateFile(dir, "svm", []string{"binary"}, t)
+
+	tests := []struct {
+		name             string
+		plugin           domain.Plugin
+		installedPackage domain.InstalledPackage
+		want             []string
+	}{
+		{
+			name:             "executables of package directory",
+			plugin:           domain.PluginInfo{Name: "node", ExecutableRelativePath: "bin"},
+			installedPackage: domain.InstalledPackage{Path: packageDir},
+			want:             []string{"node", "npm"},
+		},
+		{
+			name:             "raw package",
+			plugin:           domain.PluginInfo{Name: "svm"},
+			installedPackage: domain.InstalledPackage{Path: filepath.Join(dir, "svm")},
+			want:             []string{"svm"},
+		},
+		{
+			name:             "missing executable directory",
+			plugin:           domain.PluginInfo{Name: "node", ExecutableRelativePath: "missing"},
+			installedPackage: domain.InstalledPackage{Path: packageDir},
+			want:             nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findExecutables(tt.plugin, tt.installedPackage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findExecutables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@
 package svm
 
 import (
+	"context"
 	"github.com/pkk82/soft-ver-man/util/github"
 	"runtime"
 	"strings"
@@ -32,6 +33,6 @@ var predicate = func(name string) bool {
 	return strings.Contains(name, runtime.GOARCH+"-"+runtime.GOOS)
 }
 
-func getSupportedPackages() ([]github.Asset, error) {
-	return github.GetSupportedAssets(RepoOwner, RepoName, PageSize, predicate)
+func getSupportedPackages(ctx context.Context) ([]github.Asset, error) {
+	return github.GetSupportedAssets(ctx, RepoOwner, RepoName, PageSize, predicate)
 }
@@ -0,0 +1,218 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/project"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/download"
+	"github.com/pkk82/soft-ver-man/util/file"
+	"github.com/pkk82/soft-ver-man/util/verification"
+	"path"
+	"path/filepath"
+	"runtime"
+)
+
+const envrcSectionBegin = "### soft-ver-man sync"
+const envrcSectionEnd = "### soft-ver-man sync end"
+
+type SyncItem struct {
+	Plugin  domain.Plugin
+	Version string
+	// Options choose distribution, e.g. vendor: tem, package: jre
+	Options map[string]string
+}
+
+// Sync installs software wanted by the project in dir, records exact artifacts in svm.lock
+// and wires them in .envrc; with frozen exactly the artifacts from svm.lock are installed
+func Sync(ctx context.Context, items []SyncItem, dir string, frozen bool) error {
+	configuration, err := config.Get()
+	if err != nil {
+		return err
+	}
+
+	lock, lockExists, err := project.ReadLock(dir)
+	if err != nil {
+		return err
+	}
+	if frozen && !lockExists {
+		return errors.New("no " + project.LockFileName + " found in " + dir)
+	}
+	tools := make([]project.Tool, len(items))
+	for i, item := range items {
+		tools[i] = project.Tool{Name: item.Plugin.Info().Name, Version: item.Version, Options: item.Options}
+	}
+	if frozen {
+		err = lock.Verify(tools, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			return err
+		}
+	}
+
+	artifacts := make([]project.LockedArtifact, 0)
+	envrcLines := make([]string, 0)
+	for _, item := range items {
+		var artifact project.LockedArtifact
+		if frozen {
+			lockedArtifact, _ := lock.Find(item.Plugin.Info().Name, runtime.GOOS, runtime.GOARCH)
+			artifact, err = syncLocked(ctx, item.Plugin, lockedArtifact, configuration.SoftwareDownloadDir)
+		} else {
+			artifact, err = syncResolved(ctx, item, configuration.SoftwareDownloadDir)
+		}
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, artifact)
+
+		lines, err := hereExports(item.Plugin, artifact.Version)
+		if err != nil {
+			return err
+		}
+		envrcLines = append(envrcLines, lines...)
+	}
+
+	if !frozen {
+		err = project.WriteLock(dir, lock.Update(tools, runtime.GOOS, runtime.GOARCH, artifacts))
+		if err != nil {
+			return err
+		}
+	}
+	return file.ReplaceSection(filepath.Join(dir, ".envrc"), envrcSectionBegin, envrcSectionEnd, envrcLines)
+}
+
+func syncResolved(ctx context.Context, item SyncItem, softwareDownloadDir string) (project.LockedArtifact, error) {
+	plugin := item.Plugin
+	selector, err := QualifySelector(plugin, item.Version, item.Options)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	version, asset, err := ResolveAsset(ctx, plugin, selector, false)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+
+	fileName := domain.DownloadedFileName(plugin, asset)
+	filePath := filepath.Join(softwareDownloadDir, plugin.Info().Name, fileName)
+	exists, err := file.FileExists(filePath)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	if !exists {
+		_, err = FetchAsset(ctx, plugin, version, asset, softwareDownloadDir, false)
+		if err != nil {
+			return project.LockedArtifact{}, err
+		}
+	}
+
+	sha256, err := verification.Sha256(filePath)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+
+	artifact := project.LockedArtifact{
+		Name:     plugin.Info().Name,
+		Wanted:   item.Version,
+		Options:  item.Options,
+		Os:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Version:  version.Value,
+		Url:      asset.Url,
+		FileName: fileName,
+		Type:     string(asset.Type),
+		Sha256:   sha256,
+		Features: asset.Features,
+	}
+	return artifact, installArtifact(ctx, plugin, artifact, filePath)
+}
+
+func syncLocked(ctx context.Context, plugin domain.Plugin, artifact project.LockedArtifact, softwareDownloadDir string) (project.LockedArtifact, error) {
+	version, err := domain.NewVersion(artifact.Version)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	if installedPackages.IsInstalled(version) {
+		console.Info(fmt.Sprintf("%v %v is already installed", plugin.Info().Name, version.Value))
+		return artifact, nil
+	}
+
+	filePath, err := download.FetchFile(ctx, artifact.Url, filepath.Join(softwareDownloadDir, plugin.Info().Name), artifact.FileName)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	err = verification.VerifySha256(filePath, artifact.Sha256)
+	if err != nil {
+		return project.LockedArtifact{}, err
+	}
+	return artifact, installArtifact(ctx, plugin, artifact, filePath)
+}
+
+func installArtifact(ctx context.Context, plugin domain.Plugin, artifact project.LockedArtifact, filePath string) error {
+	version, err := domain.NewVersion(artifact.Version)
+	if err != nil {
+		return err
+	}
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return err
+	}
+	if installedPackages.IsInstalled(version) {
+		return nil
+	}
+	return Install(ctx, plugin, artifact.Version, InstallOptions{ArchivePath: &filePath, Features: artifact.Features})
+}
+
+func hereExports(plugin domain.Plugin, inputVersion string) ([]string, error) {
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return nil, err
+	}
+	installedPackage, err := findInstalledPackage(installedPackages, inputVersion)
+	if err != nil {
+		return nil, err
+	}
+	return exportsOf(installedPackages, installedPackage)
+}
+
+// exportsOf prepares export lines making installed package the one used, referring to variables of rc files if possible
+func exportsOf(installedPackages domain.InstalledPackages, installedPackage domain.InstalledPackage) ([]string, error) {
+	plugin := installedPackages.Plugin
+	envVariables, err := installedPackages.PrepareEnvVariables(plugin, config.VersionGranularity(plugin.Info()))
+	if err != nil {
+		return nil, err
+	}
+	toHere, err := envVariables.ExtractToHere(path.Base(installedPackage.Path))
+	if err != nil {
+		// package is not the newest one of its version group, so there is no variable to refer to
+		toHere = domain.DirectEnvVariables(plugin, installedPackage)
+	}
+	return toHere.ToEvalExport(), nil
+}
@@ -24,37 +24,53 @@ package software
 import (
 	"github.com/pkk82/soft-ver-man/config"
 	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/console"
 	"os"
 )
 
 func Uninstall(plugin domain.Plugin, inputVersion string) error {
-	version, err := domain.NewVersion(inputVersion)
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
 	if err != nil {
 		return err
 	}
 
-	installedPackages, err := config.LoadInstalledPackages(plugin.Name)
+	removedItem, err := removeInstalledPackage(&installedPackages, inputVersion)
 	if err != nil {
 		return err
 	}
+	version := removedItem.Version
 
-	removedItem := installedPackages.RemoveByVersion(version)
-
-	err = os.RemoveAll(removedItem.Path)
+	err = config.StoreInstalledPackages(installedPackages)
 	if err != nil {
 		return err
 	}
 
-	err = config.StoreInstalledPackages(installedPackages)
-	if err != nil {
-		return err
+	if hook, ok := plugin.(domain.InstallHook); ok {
+		err = hook.PostUninstall(version)
+		if err != nil {
+			console.Warn(err)
+		}
 	}
 
-	err = plugin.PostUninstall(version)
+	err = notifyEnvChange()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return reshimIfEnabled()
 
 }
+
+// removeInstalledPackage deletes only version installed exactly as given, ranges or aliases could pick unintended one
+func removeInstalledPackage(installedPackages *domain.InstalledPackages, inputVersion string) (domain.InstalledPackage, error) {
+	installedPackage, err := findExactInstalledPackage(*installedPackages, inputVersion)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	removedItem := installedPackages.RemoveByVersion(installedPackage.Version)
+	err = os.RemoveAll(removedItem.Path)
+	if err != nil {
+		return domain.InstalledPackage{}, err
+	}
+	return *removedItem, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package software
+
+import (
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_removeInstalledPackage(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputVersion string
+		wantErr      bool
+		wantRemained []string
+	}{
+		{name: "exact version", inputVersion: "20.1.0", wantRemained: []string{"20.2.0"}},
+		{name: "range", inputVersion: "20", wantErr: true, wantRemained: []string{"20.1.0", "20.2.0"}},
+		{name: "constraint", inputVersion: "^20.1", wantErr: true, wantRemained: []string{"20.1.0", "20.2.0"}},
+		{name: "channel", inputVersion: "latest", wantErr: true, wantRemained: []string{"20.1.0", "20.2.0"}},
+		{name: "not installed", inputVersion: "18.1.0", wantErr: true, wantRemained: []string{"20.1.0", "20.2.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := test.CreateTestDir(t)
+			installedPackages := domain.InstalledPackages{Plugin: domain.PluginInfo{Name: "node"}}
+			for _, version := range []string{"20.1.0", "20.2.0"} {
+				path := filepath.Join(dir, version)
+				test.CreateDir(path, t)
+				installedPackages.Add(domain.InstalledPackage{Version: domain.Ver(version, t), Path: path})
+			}
+
+			removed, err := removeInstalledPackage(&installedPackages, tt.inputVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("removeInstalledPackage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := os.Stat(removed.Path); !tt.wantErr && !os.IsNotExist(err) {
+				t.Errorf("removeInstalledPackage() kept directory of %v", removed.Version.Value)
+			}
+			if !reflect.DeepEqual(installedPackages.Versions(), tt.wantRemained) {
+				t.Errorf("removeInstalledPackage() remained = %v, want %v", installedPackages.Versions(), tt.wantRemained)
+			}
+			for _, version := range tt.wantRemained {
+				if _, err := os.Stat(filepath.Join(dir, version)); err != nil {
+					t.Errorf("removeInstalledPackage() deleted directory of %v", version)
+				}
+			}
+		})
+	}
+}
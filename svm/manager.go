@@ -0,0 +1,194 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+// Package svm allows to manage software versions from other Go programs,
+// errors are returned to the caller instead of terminating the process.
+package svm
+
+import (
+	"context"
+	"errors"
+	"github.com/pkk82/soft-ver-man/config"
+	"github.com/pkk82/soft-ver-man/domain"
+	"github.com/pkk82/soft-ver-man/software"
+	_ "github.com/pkk82/soft-ver-man/software/golang"
+	_ "github.com/pkk82/soft-ver-man/software/intellij"
+	_ "github.com/pkk82/soft-ver-man/software/java"
+	_ "github.com/pkk82/soft-ver-man/software/kotlin"
+	_ "github.com/pkk82/soft-ver-man/software/maven"
+	_ "github.com/pkk82/soft-ver-man/software/node"
+	_ "github.com/pkk82/soft-ver-man/software/svm"
+	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
+	"github.com/spf13/viper"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+type Config struct {
+	// ConfigFile keeps installed packages, it is created when missing
+	ConfigFile          string
+	SoftwareDir         string
+	SoftwareDownloadDir string
+}
+
+type Option func(manager *Manager)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(manager *Manager) {
+		manager.httpClient = client
+	}
+}
+
+// WithOutput redirects progress and informational messages
+func WithOutput(writer io.Writer) Option {
+	return func(manager *Manager) {
+		manager.output = writer
+	}
+}
+
+// Manager shares process wide configuration with the svm command, so only one manager should be used at a time
+type Manager struct {
+	config     Config
+	httpClient *http.Client
+	output     io.Writer
+}
+
+type InstallOptions struct {
+	VerifyChecksum bool
+	ArchivePath    string
+	Main           bool
+	PreReleases    bool
+}
+
+func NewManager(config Config, options ...Option) (*Manager, error) {
+	if config.ConfigFile == "" || config.SoftwareDir == "" || config.SoftwareDownloadDir == "" {
+		return nil, errors.New("config file, software directory and software download directory are required")
+	}
+	manager := &Manager{config: config}
+	for _, option := range options {
+		option(manager)
+	}
+	err := manager.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if manager.output != nil {
+		console.SetOutput(manager.output)
+	}
+	return manager, nil
+}
+
+func (manager *Manager) loadConfig() error {
+	err := os.MkdirAll(filepath.Dir(manager.config.ConfigFile), 0700)
+	if err != nil {
+		return err
+	}
+	viper.SetConfigFile(manager.config.ConfigFile)
+	viper.SetConfigType("yml")
+	err = viper.SafeWriteConfigAs(manager.config.ConfigFile)
+	var alreadyExists viper.ConfigFileAlreadyExistsError
+	if err != nil && !errors.As(err, &alreadyExists) {
+		return err
+	}
+	err = viper.ReadInConfig()
+	if err != nil {
+		return err
+	}
+	viper.Set(config.SoftwareDirKey, manager.config.SoftwareDir)
+	viper.Set(config.SoftwareDownloadDirKey, manager.config.SoftwareDownloadDir)
+	return nil
+}
+
+func (manager *Manager) Install(ctx context.Context, name, version string, options InstallOptions) error {
+	ctx, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return err
+	}
+	return software.Install(ctx, plugin, version, software.InstallOptions{
+		VerifyChecksum: &options.VerifyChecksum,
+		ArchivePath:    &options.ArchivePath,
+		Main:           &options.Main,
+		PreReleases:    &options.PreReleases,
+	})
+}
+
+func (manager *Manager) Uninstall(ctx context.Context, name, version string) error {
+	_, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return err
+	}
+	return software.Uninstall(plugin, version)
+}
+
+func (manager *Manager) Fetch(ctx context.Context, name, version string, verifyChecksum, preReleases bool) (FetchedPackage, error) {
+	ctx, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return FetchedPackage{}, err
+	}
+	return software.Fetch(ctx, plugin, version, manager.config.SoftwareDownloadDir, verifyChecksum, preReleases)
+}
+
+func (manager *Manager) ListInstalled(ctx context.Context, name string) ([]InstalledPackage, error) {
+	_, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	installedPackages, err := config.LoadInstalledPackages(plugin.Info().Name)
+	if err != nil {
+		return nil, err
+	}
+	return installedPackages.Items, nil
+}
+
+func (manager *Manager) ListAvailable(ctx context.Context, name string) ([]Asset, error) {
+	ctx, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return software.ListAvailable(ctx, plugin, "")
+}
+
+func (manager *Manager) SetMain(ctx context.Context, name, version string) error {
+	_, plugin, err := manager.prepare(ctx, name)
+	if err != nil {
+		return err
+	}
+	return software.SetMain(plugin, version)
+}
+
+// prepare finds plugin by name and attaches http client to the context
+func (manager *Manager) prepare(ctx context.Context, name string) (context.Context, domain.Plugin, error) {
+	if err := ctx.Err(); err != nil {
+		return ctx, nil, err
+	}
+	plugin := domain.GetPlugin(name)
+	if plugin == nil {
+		return ctx, nil, &UnknownSoftwareError{Name: name}
+	}
+	if manager.httpClient != nil {
+		ctx = web.WithClient(ctx, manager.httpClient)
+	}
+	return ctx, plugin, nil
+}
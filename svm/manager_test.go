@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package svm
+
+import (
+	"context"
+	"errors"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"path/filepath"
+	"testing"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	testDir := test.CreateTestDir(t)
+	manager, err := NewManager(Config{
+		ConfigFile:          filepath.Join(testDir, "config", "config.yml"),
+		SoftwareDir:         filepath.Join(testDir, "soft"),
+		SoftwareDownloadDir: filepath.Join(testDir, "download"),
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	return manager
+}
+
+func TestNewManager_RequiresDirs(t *testing.T) {
+	_, err := NewManager(Config{ConfigFile: "config.yml"})
+	if err == nil {
+		t.Errorf("NewManager() should fail without software directories")
+	}
+}
+
+func TestManager_Errors(t *testing.T) {
+	manager := newTestManager(t)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		target any
+	}{
+		{
+			name: "unknown software",
+			call: func() error {
+				_, err := manager.ListInstalled(context.Background(), "unknown")
+				return err
+			},
+			target: new(*UnknownSoftwareError),
+		},
+		{
+			name: "uninstall not installed",
+			call: func() error {
+				return manager.Uninstall(context.Background(), "node", "20.1.0")
+			},
+			target: new(*NotInstalledError),
+		},
+		{
+			name: "set main not installed",
+			call: func() error {
+				return manager.SetMain(context.Background(), "java", "21")
+			},
+			target: new(*NotInstalledError),
+		},
+		{
+			name: "list available not supported",
+			call: func() error {
+				_, err := manager.ListAvailable(context.Background(), "mvn")
+				return err
+			},
+			target: new(*UnsupportedError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.As(err, tt.target) {
+				t.Errorf("error = %v, want %T", err, tt.target)
+			}
+		})
+	}
+
+	t.Run("canceled context", func(t *testing.T) {
+		_, err := manager.Fetch(canceled, "node", "20", false, false)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Fetch() error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestManager_ListInstalled(t *testing.T) {
+	manager := newTestManager(t)
+	installed, err := manager.ListInstalled(context.Background(), "go")
+	if err != nil {
+		t.Fatalf("ListInstalled() error = %v", err)
+	}
+	if len(installed) != 0 {
+		t.Errorf("ListInstalled() = %v, want none", installed)
+	}
+}
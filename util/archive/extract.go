@@ -28,7 +28,6 @@ import (
 	"errors"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
-	"github.com/pkk82/soft-ver-man/util/console"
 	io2 "github.com/pkk82/soft-ver-man/util/io"
 	"io"
 	"os"
@@ -68,7 +67,10 @@ func extractZip(zipPath string, dir string, strategy domain.ExtractStrategy) (st
 	defer io2.CloseOrLog(reader)
 
 	topLevelDir := extractTopLevelDirInZipFile(reader)
-	targetFilePathSupplier := prepareTargetFilePathSupplier(zipPath, topLevelDir, strategy)
+	targetFilePathSupplier, err := prepareTargetFilePathSupplier(zipPath, topLevelDir, strategy)
+	if err != nil {
+		return "", err
+	}
 
 	for _, file := range reader.File {
 		targetFilePath := targetFilePathSupplier.supply(dir, file.Name)
@@ -151,7 +153,10 @@ func extractTarGz(tarGzFilePath, dir string, strategy domain.ExtractStrategy) (s
 	if err != nil {
 		return "", err
 	}
-	targetFilePathSupplier := prepareTargetFilePathSupplier(tarGzFilePath, topLevelDir, strategy)
+	targetFilePathSupplier, err := prepareTargetFilePathSupplier(tarGzFilePath, topLevelDir, strategy)
+	if err != nil {
+		return "", err
+	}
 
 	tarGzFile, err := os.Open(tarGzFilePath)
 	if err != nil {
@@ -206,8 +211,7 @@ func extractTarGz(tarGzFilePath, dir string, strategy domain.ExtractStrategy) (s
 
 		case tar.TypeSymlink:
 			if err := os.Symlink(header.Linkname, targetFilePath); err != nil {
-				console.Fatal(err)
-				return "", err
+				return "", errors.New(fmt.Sprintf("ExtractTarGz: Symlink() failed: %s", err.Error()))
 			}
 
 		default:
@@ -302,16 +306,16 @@ func (s *archiveReplaceTargetFilePathSupplier) supply(targetDir, archiveFilePath
 	}
 }
 
-func prepareTargetFilePathSupplier(archivePath, topLevelDirInArchive string, strategy domain.ExtractStrategy) targetFilePathSupplier {
+func prepareTargetFilePathSupplier(archivePath, topLevelDirInArchive string, strategy domain.ExtractStrategy) (targetFilePathSupplier, error) {
 
 	archiveNameWithoutExtension := archiveNameWithoutExtension(archivePath)
 	switch strategy {
 	case domain.UseCompressedDirOrArchiveName:
-		return &defaultTargetFilePathSupplier{archiveNameWithoutExtension: archiveNameWithoutExtension, topLevelDirInArchive: topLevelDirInArchive}
+		return &defaultTargetFilePathSupplier{archiveNameWithoutExtension: archiveNameWithoutExtension, topLevelDirInArchive: topLevelDirInArchive}, nil
 	case domain.ReplaceCompressedDirWithArchiveName:
-		return &archiveReplaceTargetFilePathSupplier{archiveNameWithoutExtension: archiveNameWithoutExtension, topLevelDirInArchive: topLevelDirInArchive}
+		return &archiveReplaceTargetFilePathSupplier{archiveNameWithoutExtension: archiveNameWithoutExtension, topLevelDirInArchive: topLevelDirInArchive}, nil
 	default:
-		panic("Unknown target dir name strategy: " + string(strategy))
+		return nil, errors.New("Unknown target dir name strategy: " + string(strategy))
 	}
 
 }
@@ -22,17 +22,37 @@
 
 package console
 
-import "os"
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+var output io.Writer = os.Stderr
+
+// SetOutput redirects messages, e.g. when svm is embedded in other program
+func SetOutput(writer io.Writer) {
+	output = writer
+}
+
+func Output() io.Writer {
+	return output
+}
 
 func Info(message string) {
-	println(message)
+	_, _ = fmt.Fprintln(output, message)
 }
 
 func Fatal(error error) {
-	println(error.Error())
+	_, _ = fmt.Fprintln(output, error.Error())
 	os.Exit(1)
 }
 
 func Error(error error) {
-	println(error.Error())
+	_, _ = fmt.Fprintln(output, error.Error())
+}
+
+// Warn reports failure which does not stop the operation
+func Warn(error error) {
+	_, _ = fmt.Fprintln(output, "Warning: "+error.Error())
 }
@@ -25,8 +25,10 @@ import (
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 )
 
 type CopiedPackage struct {
@@ -76,3 +78,57 @@ func Copy(fetchedPackage domain.FetchedPackage, dir, name string) (CopiedPackage
 
 	return CopiedPackage{Version: fetchedPackage.Version, PathToFile: dir, FileName: name}, nil
 }
+
+// CopyDir copies content of src directory to dst directory keeping file modes and symbolic links
+func CopyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(srcPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(src, srcPath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dst, relative)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(dstPath, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(srcPath)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dstPath)
+		default:
+			return copyFile(srcPath, dstPath, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(srcFile *os.File) {
+		err := srcFile.Close()
+		if err != nil {
+			console.Error(err)
+		}
+	}(srcFile)
+
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dstFile, srcFile)
+	if err != nil {
+		_ = dstFile.Close()
+		return err
+	}
+	return dstFile.Close()
+}
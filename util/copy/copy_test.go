@@ -25,6 +25,7 @@ package copy
 import (
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
 	"path"
 	"path/filepath"
 	"reflect"
@@ -77,3 +78,36 @@ func TestCopy(t *testing.T) {
 		})
 	}
 }
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "copied")
+	err := os.MkdirAll(filepath.Join(src, "options"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(src, "options", "editor.xml"), []byte("<application/>"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = CopyDir(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dst, "options", "editor.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "<application/>" {
+		t.Errorf("CopyDir() content = %v", string(content))
+	}
+	info, err := os.Stat(filepath.Join(dst, "options", "editor.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("CopyDir() mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
@@ -23,61 +23,81 @@
 package download
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"github.com/schollz/progressbar/v3"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 )
 
-func FetchFile(url, downloadDir, fileName string) string {
-	return fetchFile(url, downloadDir, fileName, true)
+func FetchFile(ctx context.Context, url, downloadDir, fileName string) (string, error) {
+	return fetchFile(ctx, url, downloadDir, fileName, true)
 }
 
-func FetchFileSilently(url, downloadDir, fileName string) string {
-	return fetchFile(url, downloadDir, fileName, false)
+func FetchFileSilently(ctx context.Context, url, downloadDir, fileName string) (string, error) {
+	return fetchFile(ctx, url, downloadDir, fileName, false)
 }
 
-func fetchFile(url, downloadDir, fileName string, useProgressBar bool) string {
-	response, err := http.Get(url)
+func fetchFile(ctx context.Context, url, downloadDir, fileName string, useProgressBar bool) (string, error) {
+	response, err := web.Get(ctx, url)
 	if err != nil {
-		console.Fatal(err)
+		return "", err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			fmt.Println(err)
+			console.Error(err)
 		}
 	}(response.Body)
 
 	err = os.MkdirAll(downloadDir, os.ModePerm)
 	if err != nil {
-		console.Fatal(err)
+		return "", err
 	}
 
-	file, err := os.Create(filepath.Join(downloadDir, fileName))
+	filePath := filepath.Join(downloadDir, fileName)
+	file, err := os.Create(filePath)
 	if err != nil {
-		console.Fatal(err)
+		return "", err
 	}
 	defer func(file *os.File) {
 		err := file.Close()
 		if err != nil {
-			fmt.Println(err)
+			console.Error(err)
 		}
 	}(file)
 
 	if useProgressBar {
-		bar := progressbar.DefaultBytes(response.ContentLength, "Downloading "+fileName)
+		bar := newProgressBar(response.ContentLength, "Downloading "+fileName)
 		_, err = io.Copy(io.MultiWriter(file, bar), response.Body)
 	} else {
 		_, err = io.Copy(file, response.Body)
 	}
-
 	if err != nil {
-		console.Fatal(err)
+		return "", err
 	}
 
-	return filepath.Join(downloadDir, fileName)
+	return filePath, nil
+}
+
+func newProgressBar(maxBytes int64, description string) *progressbar.ProgressBar {
+	return progressbar.NewOptions64(
+		maxBytes,
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(console.Output()),
+		progressbar.OptionShowBytes(true),
+		progressbar.OptionSetWidth(10),
+		progressbar.OptionThrottle(65*time.Millisecond),
+		progressbar.OptionShowCount(),
+		progressbar.OptionOnCompletion(func() {
+			_, _ = fmt.Fprint(console.Output(), "\n")
+		}),
+		progressbar.OptionSpinnerType(14),
+		progressbar.OptionFullWidth(),
+		progressbar.OptionSetRenderBlankState(true),
+	)
 }
@@ -114,6 +114,24 @@ func FileExists(filePath string) (bool, error) {
 	return true, nil
 }
 
+func DirExists(dirPath string) (bool, error) {
+	fileinfo, err := os.Stat(dirPath)
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if !fileinfo.IsDir() {
+		return false, errors.New("Not a directory: " + dirPath)
+	}
+
+	return true, nil
+}
+
 func Extension(fp string) domain.Type {
 	ext := filepath.Ext(fp)
 	name := filepath.Base(fp)
@@ -177,3 +195,38 @@ func AssertFileWithContent(filePath string, seekLine string, contentToAdd []stri
 	return nil
 
 }
+
+// ReplaceSection replaces lines between beginLine and endLine (both kept) with given lines,
+// the section is appended at the end of the file if it does not exist yet
+func ReplaceSection(filePath, beginLine, endLine string, lines []string) error {
+	exists, err := FileExists(filePath)
+	if err != nil {
+		return err
+	}
+	content := ""
+	if exists {
+		content, err = ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	section := strings.Join(append(append([]string{beginLine}, lines...), endLine), "\n")
+	beginIndex := strings.Index(content, beginLine)
+	endIndex := -1
+	if beginIndex >= 0 {
+		endIndex = strings.Index(content[beginIndex:], endLine)
+	}
+
+	var newContent string
+	if beginIndex >= 0 && endIndex >= 0 {
+		newContent = content[:beginIndex] + section + content[beginIndex+endIndex+len(endLine):]
+	} else if content == "" {
+		newContent = section + "\n"
+	} else if strings.HasSuffix(content, "\n") {
+		newContent = content + section + "\n"
+	} else {
+		newContent = content + "\n" + section + "\n"
+	}
+	return OverrideFileWithContent(filePath, []string{newContent})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/file"
 	"github.com/pkk82/soft-ver-man/util/test"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -112,6 +113,50 @@ func TestAppendFileWithContent(t *testing.T) {
 	}
 }
 
+func TestReplaceSection(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		existingContent []string
+		newContent      []string
+		expectedContent []string
+	}{
+		{"no file", nil, []string{"new content"}, []string{"# begin", "new content", "# end", ""}},
+		{"existing file without section", []string{"existing content"}, []string{"new content"},
+			[]string{"existing content", "# begin", "new content", "# end", ""}},
+		{"existing file with section", []string{"existing content", "# begin", "old content", "# end", "other content", ""}, []string{"new content", "newer content"},
+			[]string{"existing content", "# begin", "new content", "newer content", "# end", "other content", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := test.CreateTestDir(t)
+
+			if tt.existingContent != nil {
+				test.CreateFile(dir, "file-"+tt.name, tt.existingContent, t)
+			}
+
+			filePath := filepath.Join(dir, "file-"+tt.name)
+			err := file.ReplaceSection(filePath, "# begin", "# end", tt.newContent)
+
+			if err != nil {
+				t.Errorf("Error: %s", err)
+			}
+
+			actualContent, err := file.ReadFile(filePath)
+
+			if err != nil {
+				t.Errorf("Error: %s", err)
+			}
+
+			if actualContent != strings.Join(tt.expectedContent, "\n") {
+				t.Errorf("Expected content: %s, got: %s", strings.Join(tt.expectedContent, "\n"), actualContent)
+			}
+
+		})
+	}
+}
+
 func TestExtension(t *testing.T) {
 
 	tests := []struct {
@@ -138,3 +183,22 @@ func TestExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestDirExists(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "file")
+	err := os.WriteFile(filePath, []byte("content"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exists, err := file.DirExists(dir); !exists || err != nil {
+		t.Errorf("DirExists(%v) = %v, %v", dir, exists, err)
+	}
+	if exists, err := file.DirExists(filepath.Join(dir, "missing")); exists || err != nil {
+		t.Errorf("DirExists() of missing dir = %v, %v", exists, err)
+	}
+	if _, err := file.DirExists(filePath); err == nil {
+		t.Errorf("DirExists() of file expected error")
+	}
+}
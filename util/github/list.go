@@ -23,18 +23,19 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/domain"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/web"
 	"io"
-	"net/http"
 	"path/filepath"
 	"regexp"
 	"strings"
 )
 
-func GetSupportedAssets(repoOwner, repoName string, pageSize int, predicate func(string) bool) ([]Asset, error) {
+func GetSupportedAssets(ctx context.Context, repoOwner, repoName string, pageSize int, predicate func(string) bool) ([]Asset, error) {
 	nextPageUrl := URL(repoOwner, repoName) + fmt.Sprintf("?per_page=%d", pageSize)
 
 	var allPackages []Asset
@@ -43,7 +44,7 @@ func GetSupportedAssets(repoOwner, repoName string, pageSize int, predicate func
 	var packages []JsonRelease
 	for {
 
-		packages, nextPageUrl, err = getPageOfSupportedReleases(nextPageUrl)
+		packages, nextPageUrl, err = getPageOfSupportedReleases(ctx, nextPageUrl)
 		if err != nil {
 			return nil, err
 		}
@@ -109,15 +110,12 @@ type Asset struct {
 	Type    domain.Type
 }
 
-func getPageOfSupportedReleases(url string) ([]JsonRelease, string, error) {
+func getPageOfSupportedReleases(ctx context.Context, url string) ([]JsonRelease, string, error) {
 
-	resp, err := http.Get(url)
+	resp, err := web.Get(ctx, url)
 	if err != nil {
 		return nil, "", err
 	}
-	if resp.StatusCode != 200 {
-		return nil, "", fmt.Errorf("HTTP status code for fetching releases %v", resp.StatusCode)
-	}
 
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
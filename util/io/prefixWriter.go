@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package io
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter writes complete lines preceded by prefix, writers sharing the same mutex do not mix their lines
+type PrefixWriter struct {
+	writer io.Writer
+	prefix string
+	mutex  *sync.Mutex
+	buffer []byte
+}
+
+func NewPrefixWriter(writer io.Writer, prefix string, mutex *sync.Mutex) *PrefixWriter {
+	return &PrefixWriter{writer: writer, prefix: prefix, mutex: mutex}
+}
+
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+	for {
+		index := bytes.IndexByte(w.buffer, '\n')
+		if index < 0 {
+			return len(p), nil
+		}
+		err := w.writeLine(w.buffer[:index+1])
+		w.buffer = w.buffer[index+1:]
+		if err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush writes remaining incomplete line
+func (w *PrefixWriter) Flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	err := w.writeLine(append(w.buffer, '\n'))
+	w.buffer = nil
+	return err
+}
+
+func (w *PrefixWriter) writeLine(line []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, err := w.writer.Write(append([]byte(w.prefix), line...))
+	return err
+}
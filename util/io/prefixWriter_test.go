@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package io
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{name: "single line", writes: []string{"ok\n"}, want: "[18] ok\n"},
+		{name: "line split across writes", writes: []string{"o", "k\nne", "xt\n"}, want: "[18] ok\n[18] next\n"},
+		{name: "incomplete line flushed", writes: []string{"ok\nlast"}, want: "[18] ok\n[18] last\n"},
+		{name: "nothing written", writes: []string{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer := NewPrefixWriter(&buffer, "[18] ", &sync.Mutex{})
+			for _, write := range tt.writes {
+				_, err := writer.Write([]byte(write))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := writer.Flush()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buffer.String() != tt.want {
+				t.Errorf("PrefixWriter wrote %q, want %q", buffer.String(), tt.want)
+			}
+		})
+	}
+}
@@ -25,14 +25,14 @@ package pgp
 import (
 	"bytes"
 	"context"
-	"errors"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"github.com/pkk82/soft-ver-man/util/verification"
 	"golang.org/x/crypto/openpgp"
 	"io"
 	"os"
 )
 
-func VerifySignature(filePath, signatureFilePath string, publicKeyFilePaths []string) {
+func VerifySignature(filePath, signatureFilePath string, publicKeyFilePaths []string) error {
 	errs := make(chan error)
 	resultCh := make(chan bool)
 
@@ -71,12 +71,11 @@ func VerifySignature(filePath, signatureFilePath string, publicKeyFilePaths []st
 
 	result := <-resultCh
 
-	if result {
-		console.Info(filePath + " is correct file")
-	} else {
-		console.Fatal(errors.New(filePath + " is corrupted file"))
+	if !result {
+		return &verification.CorruptedFileError{FilePath: filePath, Reason: "signature verification failed"}
 	}
-
+	console.Info(filePath + " is correct file")
+	return nil
 }
 
 func verifySignature(filePath, signatureFilePath, publicKeyFilePath string) error {
@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package process
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Run runs executable in a child process with the given environment, forwarding signals to it,
+// and returns exit code of the child process
+func Run(executable string, args []string, environ []string) (int, error) {
+	return RunWithStreams(executable, args, environ, os.Stdin, os.Stdout, os.Stderr)
+}
+
+func RunWithStreams(executable string, args []string, environ []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	command := exec.Command(executable, args...)
+	command.Env = environ
+	command.Stdin = stdin
+	command.Stdout = stdout
+	command.Stderr = stderr
+
+	// terminal delivers SIGINT and SIGQUIT to the whole foreground process group, so the child gets them itself,
+	// they are only caught to let the child decide when to exit
+	terminalSignals := make(chan os.Signal, 1)
+	signal.Notify(terminalSignals, os.Interrupt, syscall.SIGQUIT)
+	defer signal.Stop(terminalSignals)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	err := command.Start()
+	if err != nil {
+		return -1, err
+	}
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case s := <-signals:
+				_ = command.Process.Signal(s)
+			case <-terminalSignals:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err = command.Wait()
+	close(done)
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		if status, ok := exitError.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			// the same code as shells give for a child killed by a signal
+			return 128 + int(status.Signal()), nil
+		}
+		return exitError.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// LookPath searches for executable in directories of PATH of the given environment
+func LookPath(file string, environ []string) (string, error) {
+	if strings.ContainsRune(file, os.PathSeparator) || strings.ContainsRune(file, '/') {
+		return exec.LookPath(file)
+	}
+	pathValue := ""
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		if name == "PATH" {
+			pathValue = value
+		}
+	}
+	for _, dir := range filepath.SplitList(pathValue) {
+		if dir == "" {
+			continue
+		}
+		executable, err := exec.LookPath(filepath.Join(dir, file))
+		if err == nil {
+			return executable, nil
+		}
+	}
+	return "", fmt.Errorf("executable %v not found in PATH", file)
+}
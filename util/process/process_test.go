@@ -0,0 +1,150 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package process
+
+import (
+	"bufio"
+	"github.com/pkk82/soft-ver-man/util/test"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLookPath(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	firstDir := filepath.Join(dir, "first")
+	secondDir := filepath.Join(dir, "second")
+	test.CreateDir(firstDir, t)
+	test.CreateDir(secondDir, t)
+	test.CreateFile(firstDir, "mvn", []string{"#!/bin/sh"}, t)
+	test.CreateFile(secondDir, "mvn", []string{"#!/bin/sh"}, t)
+	test.CreateFile(secondDir, "java", []string{"#!/bin/sh"}, t)
+	for _, executable := range []string{filepath.Join(secondDir, "mvn"), filepath.Join(secondDir, "java")} {
+		err := os.Chmod(executable, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.Chmod(filepath.Join(firstDir, "mvn"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	environ := []string{"HOME=/home/user", "PATH=" + firstDir + string(os.PathListSeparator) + secondDir}
+	tests := []struct {
+		name    string
+		file    string
+		want    string
+		wantErr bool
+	}{
+		{name: "skip not executable file", file: "mvn", want: filepath.Join(secondDir, "mvn")},
+		{name: "found in second dir", file: "java", want: filepath.Join(secondDir, "java")},
+		{name: "path to file", file: filepath.Join(secondDir, "java"), want: filepath.Join(secondDir, "java")},
+		{name: "not found", file: "node", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookPath(tt.file, environ)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LookPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithStreams(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	tests := []struct {
+		name   string
+		script []string
+		want   int
+	}{
+		{name: "success", script: []string{"#!/bin/sh", "exit 0"}, want: 0},
+		{name: "exit code", script: []string{"#!/bin/sh", "exit 3"}, want: 3},
+		{name: "killed by signal", script: []string{"#!/bin/sh", "kill -TERM $$"}, want: 128 + int(syscall.SIGTERM)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.CreateFile(dir, "run", tt.script, t)
+			executable := filepath.Join(dir, "run")
+			err := os.Chmod(executable, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := RunWithStreams(executable, nil, os.Environ(), nil, io.Discard, io.Discard)
+			if err != nil {
+				t.Fatalf("RunWithStreams() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RunWithStreams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithStreams_signals(t *testing.T) {
+	dir := test.CreateTestDir(t)
+	test.CreateFile(dir, "run", []string{"#!/bin/sh", "trap 'echo INT' INT", "trap 'echo TERM; exit 7' TERM", "echo ready", "while true; do sleep 0.1; done"}, t)
+	executable := filepath.Join(dir, "run")
+	err := os.Chmod(executable, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, writer := io.Pipe()
+	codes := make(chan int, 1)
+	go func() {
+		code, err := RunWithStreams(executable, nil, os.Environ(), nil, writer, io.Discard)
+		if err != nil {
+			t.Errorf("RunWithStreams() unexpected error: %v", err)
+		}
+		codes <- code
+		_ = writer.Close()
+	}()
+	lines := bufio.NewScanner(reader)
+	if !lines.Scan() || lines.Text() != "ready" {
+		t.Fatalf("child process has not started: %v", lines.Err())
+	}
+
+	// SIGINT comes from terminal to the child too, so it is not forwarded
+	_ = syscall.Kill(os.Getpid(), syscall.SIGINT)
+	time.Sleep(300 * time.Millisecond)
+	_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+
+	var got []string
+	for lines.Scan() {
+		got = append(got, lines.Text())
+	}
+	if !reflect.DeepEqual(got, []string{"TERM"}) {
+		t.Errorf("child process received %v, want only [TERM]", got)
+	}
+	if code := <-codes; code != 7 {
+		t.Errorf("RunWithStreams() = %v, want 7", code)
+	}
+}
@@ -43,6 +43,13 @@ func CreateTestDir(t *testing.T) string {
 	return testDir
 }
 
+func CreateDir(path string, t *testing.T) {
+	err := os.MkdirAll(path, os.ModePerm)
+	if err != nil {
+		t.Fatalf("Failed to create directory: %s", err)
+	}
+}
+
 func CreateFile(path, fileName string, content []string, t *testing.T) {
 	err := os.WriteFile(filepath.Join(path, fileName), []byte(join(content)), os.ModePerm)
 	if err != nil {
@@ -23,36 +23,69 @@
 package verification
 
 import (
+	"crypto/sha1"
 	"crypto/sha256"
 	"fmt"
 	"github.com/pkk82/soft-ver-man/util/console"
+	"hash"
 	"io"
 	"os"
 )
 
+// CorruptedFileError is returned when file does not match its checksum or signature
+type CorruptedFileError struct {
+	FilePath string
+	Reason   string
+}
+
+func (e *CorruptedFileError) Error() string {
+	if e.Reason == "" {
+		return e.FilePath + " is corrupted file"
+	}
+	return e.FilePath + " is corrupted file (" + e.Reason + ")"
+}
+
 func VerifySha256(filePath, expectedHash string) error {
+	return verify(filePath, expectedHash, Sha256)
+}
 
-	file, err := os.Open(filePath)
+func VerifySha1(filePath, expectedHash string) error {
+	return verify(filePath, expectedHash, Sha1)
+}
+
+func verify(filePath, expectedHash string, hashOf func(string) (string, error)) error {
+	fileHash, err := hashOf(filePath)
 	if err != nil {
 		return err
 	}
+	if fileHash == expectedHash {
+		return nil
+	} else {
+		return &CorruptedFileError{FilePath: filePath, Reason: "expected hash: " + expectedHash + ", actual hash: " + fileHash}
+	}
+}
+
+func Sha256(filePath string) (string, error) {
+	return hashFile(filePath, sha256.New())
+}
+
+func Sha1(filePath string) (string, error) {
+	return hashFile(filePath, sha1.New())
+}
+
+func hashFile(filePath string, h hash.Hash) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
 	defer func(file *os.File) {
 		err := file.Close()
 		if err != nil {
 			console.Error(err)
 		}
 	}(file)
-
-	h := sha256.New()
 	if _, err := io.Copy(h, io.Reader(file)); err != nil {
-		return err
-	}
-
-	fileHash := fmt.Sprintf("%x", h.Sum(nil))
-
-	if fileHash == expectedHash {
-		return nil
-	} else {
-		return fmt.Errorf(filePath + " is corrupted file (expected hash: " + expectedHash + ", actual hash: " + fileHash + ")")
+		return "", err
 	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
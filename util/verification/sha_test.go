@@ -105,3 +105,15 @@ func TestVerifySha256(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySha1(t *testing.T) {
+	testDir := test.CreateTestDir(t)
+	test.CreateFile(testDir, "empty.txt", []string{}, t)
+	filePath := filepath.Join(testDir, "empty.txt")
+	if err := VerifySha1(filePath, "da39a3ee5e6b4b0d3255bfef95601890afd80709"); err != nil {
+		t.Errorf("VerifySha1() error = %v", err)
+	}
+	if err := VerifySha1(filePath, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"); err == nil {
+		t.Errorf("VerifySha1() expected error")
+	}
+}
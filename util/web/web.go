@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2024 Piotr Kozak <piotrkrzysztofkozak@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type clientKey struct{}
+
+// HTTPError is returned when server responds with status other than 200 OK
+type HTTPError struct {
+	Url        string
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP status %d for %v", e.StatusCode, e.Url)
+}
+
+// WithClient makes all requests done with the context use the client
+func WithClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+func Client(ctx context.Context) *http.Client {
+	client, ok := ctx.Value(clientKey{}).(*http.Client)
+	if !ok || client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
+// Get sends GET request with client of the context, response with status other than 200 OK is an error
+func Get(ctx context.Context, url string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := Client(ctx).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+		return nil, &HTTPError{Url: url, StatusCode: response.StatusCode}
+	}
+	return response, nil
+}